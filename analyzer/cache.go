@@ -0,0 +1,23 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/afony10/cadence-workflow-linter/config"
+)
+
+// CacheKey identifies the analysis configuration that produced a set of results.
+// Any cached findings must be discarded when the key changes, which happens
+// whenever the effective ruleset or the tool version changes.
+func CacheKey(rules *config.RuleSet) (string, error) {
+	fp, err := rules.Fingerprint()
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write([]byte(ToolVersion()))
+	h.Write([]byte{0})
+	h.Write([]byte(fp))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
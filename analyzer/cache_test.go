@@ -0,0 +1,40 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/config"
+)
+
+func TestCacheKeyInvalidation(t *testing.T) {
+	rules := &config.RuleSet{
+		FunctionCalls: []config.FunctionRule{
+			{Rule: "TimeUsage", Package: "time", Functions: []string{"Now"}, Severity: "error", Message: "no clock"},
+		},
+	}
+
+	base, err := CacheKey(rules)
+	if err != nil {
+		t.Fatalf("cache key: %v", err)
+	}
+	again, _ := CacheKey(rules)
+	if base != again {
+		t.Fatalf("cache key is not stable: %s != %s", base, again)
+	}
+
+	// Editing a rule must invalidate the cache
+	rules.FunctionCalls[0].Functions = append(rules.FunctionCalls[0].Functions, "Since")
+	edited, _ := CacheKey(rules)
+	if edited == base {
+		t.Fatal("expected cache key to change after editing a rule")
+	}
+
+	// Upgrading the tool must invalidate the cache
+	old := Version
+	Version = "v9.9.9"
+	defer func() { Version = old }()
+	upgraded, _ := CacheKey(rules)
+	if upgraded == edited {
+		t.Fatal("expected cache key to change with the tool version")
+	}
+}
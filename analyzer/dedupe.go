@@ -0,0 +1,39 @@
+package analyzer
+
+import (
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+// dedupePositions keeps one issue per file position, for when several
+// detectors or rules flag the same call. The winner is the issue whose rule
+// comes first in prefer; failing that, the most severe one; failing that, the
+// first in report order. issues must be sorted, and the result stays sorted.
+func dedupePositions(issues []detectors.Issue, prefer []string) []detectors.Issue {
+	rank := make(map[string]int, len(prefer))
+	for i, r := range prefer {
+		if _, ok := rank[r]; !ok {
+			rank[r] = len(prefer) - i
+		}
+	}
+	better := func(a, b detectors.Issue) bool {
+		if rank[a.Rule] != rank[b.Rule] {
+			return rank[a.Rule] > rank[b.Rule]
+		}
+		return detectors.SeverityRank(a.Severity) > detectors.SeverityRank(b.Severity)
+	}
+
+	var kept []detectors.Issue
+	for _, is := range issues {
+		if n := len(kept); n > 0 {
+			last := &kept[n-1]
+			if last.File == is.File && last.Line == is.Line && last.Column == is.Column {
+				if better(is, *last) {
+					*last = is
+				}
+				continue
+			}
+		}
+		kept = append(kept, is)
+	}
+	return kept
+}
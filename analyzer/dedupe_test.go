@@ -0,0 +1,59 @@
+package analyzer
+
+import (
+	"go/ast"
+	"path/filepath"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+	"github.com/afony10/cadence-workflow-linter/analyzer/modutils"
+	"github.com/afony10/cadence-workflow-linter/config"
+)
+
+func TestDedupePositions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wf.go")
+	writeFile(t, path, `package app
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func ClockWorkflow(ctx workflow.Context) error {
+	_ = time.Now()
+	return nil
+}
+`)
+	// Two rule sources flag the same time.Now() call: a built-in style
+	// TimeUsage error and a broader custom warning for the whole package.
+	factory := func(mi *modutils.ModuleInfo) []ast.Visitor {
+		return []ast.Visitor{
+			detectors.NewFuncCallDetector([]config.FunctionRule{{Rule: "TimeUsage", Package: "time", Functions: []string{"Now"}, Severity: "error", Message: "time.Now"}}, nil, nil, mi),
+			detectors.NewFuncCallDetector([]config.FunctionRule{{Rule: "AnyTime", Package: "time", Functions: []string{"Now"}, Severity: "warning", Message: "time.*"}}, nil, nil, mi),
+		}
+	}
+
+	rules := func(opts Options) []string {
+		t.Helper()
+		issues, err := ScanWithOptions(path, factory, opts)
+		if err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		var got []string
+		for _, is := range issues {
+			got = append(got, is.Rule)
+		}
+		return got
+	}
+
+	if got := rules(Options{}); len(got) != 2 {
+		t.Fatalf("expected both overlapping issues without dedupe, got %v", got)
+	}
+	if got := rules(Options{DedupePositions: true}); len(got) != 1 || got[0] != "TimeUsage" {
+		t.Errorf("expected the more severe TimeUsage to win, got %v", got)
+	}
+	if got := rules(Options{DedupePositions: true, PreferredRules: []string{"AnyTime"}}); len(got) != 1 || got[0] != "AnyTime" {
+		t.Errorf("expected the preferred AnyTime to win, got %v", got)
+	}
+}
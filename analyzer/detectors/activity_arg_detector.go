@@ -0,0 +1,228 @@
+package detectors
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// ActivityArgDetector inspects the arguments passed to workflow.ExecuteActivity
+// and flags values that don't survive serialization to the activity worker.
+type ActivityArgDetector struct {
+	ctx         FileContext
+	wr          *registry.WorkflowRegistry
+	currFunc    string
+	pkgPath     string
+	issues      []Issue
+	timeStructs map[string]bool // struct types in this file holding a time.Time field
+	timeVars    map[string]bool // local variables known to hold a time.Time (or such a struct)
+	fileFuncs   map[string]bool // top-level functions declared in this file
+	funcVars    map[string]bool // local variables and parameters of func type
+}
+
+func NewActivityArgDetector() *ActivityArgDetector {
+	return &ActivityArgDetector{issues: []Issue{}}
+}
+
+func (d *ActivityArgDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *ActivityArgDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *ActivityArgDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *ActivityArgDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *ActivityArgDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.File:
+		d.timeStructs = d.collectTimeStructs(n)
+		d.fileFuncs = map[string]bool{}
+		for _, decl := range n.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+				d.fileFuncs[fn.Name.Name] = true
+			}
+		}
+
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+		d.timeVars = map[string]bool{}
+		d.funcVars = map[string]bool{}
+		if n.Type.Params != nil {
+			for _, field := range n.Type.Params.List {
+				_, isFunc := field.Type.(*ast.FuncType)
+				for _, name := range field.Names {
+					if d.isTimeType(field.Type) {
+						d.timeVars[name.Name] = true
+					}
+					if isFunc {
+						d.funcVars[name.Name] = true
+					}
+				}
+			}
+		}
+
+	case *ast.ValueSpec:
+		for i, name := range n.Names {
+			if (n.Type != nil && d.isTimeType(n.Type)) || (i < len(n.Values) && d.isTimeExpr(n.Values[i])) {
+				d.trackTimeVar(name.Name)
+			}
+			if _, ok := n.Type.(*ast.FuncType); ok || (i < len(n.Values) && d.isFuncExpr(n.Values[i])) {
+				d.trackFuncVar(name.Name)
+			}
+		}
+
+	case *ast.AssignStmt:
+		if len(n.Lhs) == len(n.Rhs) {
+			for i, lhs := range n.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if d.isTimeExpr(n.Rhs[i]) {
+					d.trackTimeVar(ident.Name)
+				}
+				if d.isFuncExpr(n.Rhs[i]) {
+					d.trackFuncVar(ident.Name)
+				}
+			}
+		}
+
+	case *ast.CallExpr:
+		if !isExecuteActivity(d.ctx.ImportMap, n) || len(n.Args) < 3 {
+			return d
+		}
+		if !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+			return d
+		}
+		for _, arg := range n.Args[2:] {
+			if d.isFuncExpr(arg) {
+				d.report(arg, "Serialization", "error", fmt.Sprintf(
+					"Passing function value %s as an activity argument. Activity inputs are serialized and functions can't be; pass plain data and move the logic into the activity.",
+					types.ExprString(arg)))
+				continue
+			}
+			if d.isTimeExpr(arg) {
+				d.report(arg, "TimeSerialization", "info", fmt.Sprintf(
+					"Passing time.Time value %s to an activity. Its monotonic clock reading is dropped when serialized; pass %s.UTC() or a Unix timestamp instead.",
+					types.ExprString(arg), types.ExprString(arg)))
+			}
+		}
+	}
+	return d
+}
+
+func (d *ActivityArgDetector) trackTimeVar(name string) {
+	if d.timeVars != nil {
+		d.timeVars[name] = true
+	}
+}
+
+func (d *ActivityArgDetector) trackFuncVar(name string) {
+	if d.funcVars != nil {
+		d.funcVars[name] = true
+	}
+}
+
+// isFuncExpr is a best-effort check for function-typed expressions: literals,
+// func-typed locals/params and references to top-level functions.
+func (d *ActivityArgDetector) isFuncExpr(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.FuncLit:
+		return true
+	case *ast.ParenExpr:
+		return d.isFuncExpr(e.X)
+	case *ast.Ident:
+		if d.funcVars[e.Name] {
+			return true
+		}
+		return d.fileFuncs[e.Name] && !d.timeVars[e.Name]
+	}
+	return false
+}
+
+func (d *ActivityArgDetector) report(arg ast.Expr, rule, severity, message string) {
+	pos := d.ctx.Fset.Position(arg.Pos())
+	d.issues = append(d.issues, Issue{
+		File:     d.ctx.File,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Rule:     rule,
+		Severity: severity,
+		Message:  message,
+		Func:     d.currFunc,
+	})
+}
+
+// collectTimeStructs finds struct types declared in the file with a time.Time field.
+func (d *ActivityArgDetector) collectTimeStructs(f *ast.File) map[string]bool {
+	structs := map[string]bool{}
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok || st.Fields == nil {
+				continue
+			}
+			for _, field := range st.Fields.List {
+				if d.isTimeTimeType(field.Type) {
+					structs[ts.Name.Name] = true
+					break
+				}
+			}
+		}
+	}
+	return structs
+}
+
+// isTimeTimeType matches time.Time and *time.Time.
+func (d *ActivityArgDetector) isTimeTimeType(expr ast.Expr) bool {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	pkg, name, ok := resolveSelector(d.ctx.ImportMap, expr)
+	return ok && pkg == "time" && name == "Time"
+}
+
+// isTimeType matches time.Time or a local struct type carrying one.
+func (d *ActivityArgDetector) isTimeType(expr ast.Expr) bool {
+	if d.isTimeTimeType(expr) {
+		return true
+	}
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	ident, ok := expr.(*ast.Ident)
+	return ok && d.timeStructs[ident.Name]
+}
+
+// isTimeExpr is a best-effort check for expressions evaluating to a time.Time.
+func (d *ActivityArgDetector) isTimeExpr(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return d.timeVars[e.Name]
+	case *ast.ParenExpr:
+		return d.isTimeExpr(e.X)
+	case *ast.UnaryExpr:
+		return d.isTimeExpr(e.X)
+	case *ast.CompositeLit:
+		return e.Type != nil && d.isTimeType(e.Type)
+	case *ast.CallExpr:
+		pkg, name, ok := resolveSelector(d.ctx.ImportMap, e.Fun)
+		if !ok {
+			return false
+		}
+		switch {
+		case pkg == "time" && (name == "Now" || name == "Date" || name == "Unix" || name == "UnixMilli"):
+			return true
+		case isWorkflowPackage(pkg) && name == "Now":
+			return true
+		}
+	}
+	return false
+}
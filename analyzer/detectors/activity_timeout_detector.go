@@ -0,0 +1,64 @@
+package detectors
+
+import (
+	"go/ast"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// ActivityTimeoutDetector flags workflow.ActivityOptions literals that set
+// neither StartToCloseTimeout nor ScheduleToCloseTimeout. The Cadence client
+// rejects such activities when they are scheduled, failing the decision task.
+// Literals are checked wherever they appear, since option builders often live
+// in shared helpers.
+type ActivityTimeoutDetector struct {
+	ctx      FileContext
+	currFunc string
+	issues   []Issue
+}
+
+func NewActivityTimeoutDetector() *ActivityTimeoutDetector {
+	return &ActivityTimeoutDetector{issues: []Issue{}}
+}
+
+func (d *ActivityTimeoutDetector) SetFileContext(ctx FileContext) { d.ctx = ctx }
+func (d *ActivityTimeoutDetector) Issues() []Issue                { return d.issues }
+
+func (d *ActivityTimeoutDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+
+	case *ast.CompositeLit:
+		pkg, name, ok := resolveSelector(d.ctx.ImportMap, n.Type)
+		if !ok || !isWorkflowPackage(pkg) || name != "ActivityOptions" || hasTimeoutField(n) {
+			return d
+		}
+		pos := d.ctx.Fset.Position(n.Lbrace)
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "MissingTimeout",
+			Severity: "error",
+			Message:  "workflow.ActivityOptions sets neither StartToCloseTimeout nor ScheduleToCloseTimeout. Activities scheduled with these options are rejected at runtime; set StartToCloseTimeout.",
+			Func:     d.currFunc,
+		})
+	}
+	return d
+}
+
+// hasTimeoutField reports whether an options literal sets a close timeout.
+// Unkeyed literals are given the benefit of the doubt.
+func hasTimeoutField(lit *ast.CompositeLit) bool {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return true
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && (key.Name == "StartToCloseTimeout" || key.Name == "ScheduleToCloseTimeout") {
+			return true
+		}
+	}
+	return false
+}
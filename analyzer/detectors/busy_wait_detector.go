@@ -0,0 +1,78 @@
+package detectors
+
+import (
+	"go/ast"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// waitFuncs are workflow functions that block until time passes or a condition holds.
+var waitFuncs = map[string]bool{
+	"Sleep":            true,
+	"NewTimer":         true,
+	"Await":            true,
+	"AwaitWithTimeout": true,
+}
+
+// BusyWaitDetector flags loops polling workflow.Now(ctx) against a deadline
+// without blocking on a timer. Workflow time only advances between decisions,
+// so such a loop spins forever or until the decision task times out.
+type BusyWaitDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	issues   []Issue
+}
+
+func NewBusyWaitDetector() *BusyWaitDetector {
+	return &BusyWaitDetector{issues: []Issue{}}
+}
+
+func (d *BusyWaitDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *BusyWaitDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *BusyWaitDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *BusyWaitDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *BusyWaitDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+
+	case *ast.ForStmt:
+		if n.Cond == nil || !d.containsWorkflowCall(n.Cond, func(name string) bool { return name == "Now" }) {
+			return d
+		}
+		if d.containsWorkflowCall(n.Body, func(name string) bool { return waitFuncs[name] }) {
+			return d
+		}
+		if !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+			return d
+		}
+		pos := d.ctx.Fset.Position(n.For)
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "BusyWait",
+			Severity: "warning",
+			Message:  "Loop waits on workflow.Now(ctx) without a timer. Workflow time doesn't advance while the loop spins; use workflow.Sleep(ctx, d) or workflow.NewTimer(ctx, d) instead.",
+			Func:     d.currFunc,
+		})
+	}
+	return d
+}
+
+// containsWorkflowCall reports whether node calls a workflow package function accepted by match.
+func (d *BusyWaitDetector) containsWorkflowCall(node ast.Node, match func(name string) bool) bool {
+	found := false
+	ast.Inspect(node, func(m ast.Node) bool {
+		if call, ok := m.(*ast.CallExpr); ok {
+			if pkg, name, ok := resolveSelector(d.ctx.ImportMap, call.Fun); ok && isWorkflowPackage(pkg) && match(name) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
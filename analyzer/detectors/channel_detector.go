@@ -2,6 +2,7 @@ package detectors
 
 import (
 	"go/ast"
+	"go/token"
 
 	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
 )
@@ -10,7 +11,9 @@ type ChannelDetector struct {
 	ctx      FileContext
 	wr       *registry.WorkflowRegistry
 	currFunc string
+	pkgPath  string
 	issues   []Issue
+	timers   map[string]string // variables holding a time.After/time.Tick channel -> constructor
 }
 
 func NewChannelDetector() *ChannelDetector {
@@ -19,12 +22,59 @@ func NewChannelDetector() *ChannelDetector {
 
 func (d *ChannelDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
 func (d *ChannelDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *ChannelDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
 func (d *ChannelDetector) Issues() []Issue                                    { return d.issues }
 
 func (d *ChannelDetector) Visit(node ast.Node) ast.Visitor {
 	switch n := node.(type) {
 	case *ast.FuncDecl:
-		d.currFunc = n.Name.Name
+		d.currFunc = registry.FuncDeclName(n)
+		d.timers = map[string]string{}
+
+	case *ast.AssignStmt:
+		if len(n.Lhs) == len(n.Rhs) {
+			for i, lhs := range n.Lhs {
+				d.trackTimer(lhs, n.Rhs[i])
+			}
+		}
+
+	case *ast.ValueSpec:
+		if len(n.Names) == len(n.Values) {
+			for i, name := range n.Names {
+				d.trackTimer(name, n.Values[i])
+			}
+		}
+
+	case *ast.UnaryExpr:
+		// <-c where c came from time.After/time.Tick earlier in the function
+		if n.Op == token.ARROW {
+			d.checkTimerReceive(n.X, n.OpPos)
+		}
+
+	case *ast.RangeStmt:
+		d.checkTimerReceive(n.X, n.For)
+
+	case *ast.SelectStmt:
+		// native select; a default clause turns it into a non-blocking poll
+		if !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+			return d
+		}
+		severity := "warning"
+		message := "Detected native select statement in workflow. Use workflow.NewSelector(ctx) instead."
+		if hasDefaultClause(n) {
+			severity = "error"
+			message = "Detected native select with a default case in workflow. A non-blocking poll busy-spins and its outcome depends on timing, so replays diverge; use workflow.NewSelector(ctx) and block on Select."
+		}
+		pos := d.ctx.Fset.Position(n.Select)
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "Concurrency",
+			Severity: severity,
+			Message:  message,
+			Func:     d.currFunc,
+		})
 
 	case *ast.CallExpr:
 		// make(chan T, ...)
@@ -47,3 +97,47 @@ func (d *ChannelDetector) Visit(node ast.Node) ast.Visitor {
 	}
 	return d
 }
+
+// trackTimer records lhs as a native timer channel when rhs is time.After(...)
+// or time.Tick(...), and forgets it when lhs is reassigned to anything else.
+func (d *ChannelDetector) trackTimer(lhs, rhs ast.Expr) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok || d.timers == nil {
+		return
+	}
+	delete(d.timers, ident.Name)
+	call, ok := rhs.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	if pkg, name, ok := resolveSelector(d.ctx.ImportMap, call.Fun); ok && pkg == "time" && (name == "After" || name == "Tick") {
+		d.timers[ident.Name] = "time." + name
+	}
+}
+
+// checkTimerReceive flags receiving from (or ranging over) a tracked timer variable.
+func (d *ChannelDetector) checkTimerReceive(x ast.Expr, at token.Pos) {
+	ident, ok := x.(*ast.Ident)
+	if !ok || d.timers[ident.Name] == "" || !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+		return
+	}
+	pos := d.ctx.Fset.Position(at)
+	d.issues = append(d.issues, Issue{
+		File:     d.ctx.File,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Rule:     "TimeUsage",
+		Severity: "error",
+		Message:  "Detected receive from " + ident.Name + ", a native timer channel created by " + d.timers[ident.Name] + "() in workflow. It fires on the wall clock and isn't replayed; use workflow.NewTimer(ctx, d) or workflow.Sleep(ctx, d) instead.",
+		Func:     d.currFunc,
+	})
+}
+
+func hasDefaultClause(sel *ast.SelectStmt) bool {
+	for _, stmt := range sel.Body.List {
+		if cc, ok := stmt.(*ast.CommClause); ok && cc.Comm == nil {
+			return true
+		}
+	}
+	return false
+}
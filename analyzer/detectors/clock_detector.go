@@ -0,0 +1,61 @@
+package detectors
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// ClockDetector flags x.Now() method calls in workflow code. Teams often hide
+// time.Now behind a Clock interface, which keeps the wall-clock read out of
+// sight of the call graph. Without type information the implementation can't
+// be resolved, so every such call is reported as a low-confidence info.
+type ClockDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	issues   []Issue
+}
+
+func NewClockDetector() *ClockDetector {
+	return &ClockDetector{issues: []Issue{}}
+}
+
+func (d *ClockDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *ClockDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *ClockDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *ClockDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *ClockDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+
+	case *ast.CallExpr:
+		sel, ok := n.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Now" || len(n.Args) != 0 {
+			return d
+		}
+		// pkg.Now() is a package function, covered by the function call rules
+		if ident, ok := sel.X.(*ast.Ident); ok && d.ctx.ImportMap[ident.Name] != "" {
+			return d
+		}
+		if !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+			return d
+		}
+		pos := d.ctx.Fset.Position(sel.Sel.Pos())
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "ClockAbstraction",
+			Severity: "info",
+			Message: "Call to " + types.ExprString(sel) + "() in workflow may read the wall clock through a clock abstraction. " +
+				"If it wraps time.Now(), use workflow.Now(ctx) instead (low confidence: the implementation couldn't be resolved).",
+			Func: d.currFunc,
+		})
+	}
+	return d
+}
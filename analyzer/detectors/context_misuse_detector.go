@@ -0,0 +1,104 @@
+package detectors
+
+import (
+	"go/ast"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// activitySchedulers are the workflow package functions that schedule work
+// whose timeouts come from workflow options.
+var activitySchedulers = map[string]bool{
+	"ExecuteActivity":      true,
+	"ExecuteLocalActivity": true,
+	"ExecuteChildWorkflow": true,
+}
+
+// ContextMisuseDetector flags standard-library context deadlines used to bound
+// activities from workflow code. A context.WithTimeout deadline runs on the
+// worker's wall clock and isn't recorded in history; activity timeouts belong
+// in the activity options.
+type ContextMisuseDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	issues   []Issue
+	derived  map[string]*ast.CallExpr // variables holding a context.WithTimeout/WithDeadline result
+	reported map[*ast.CallExpr]bool
+}
+
+func NewContextMisuseDetector() *ContextMisuseDetector {
+	return &ContextMisuseDetector{issues: []Issue{}}
+}
+
+func (d *ContextMisuseDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *ContextMisuseDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *ContextMisuseDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *ContextMisuseDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *ContextMisuseDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+		d.derived = map[string]*ast.CallExpr{}
+		d.reported = map[*ast.CallExpr]bool{}
+
+	case *ast.AssignStmt:
+		// tctx, cancel := context.WithTimeout(parent, d)
+		if d.derived == nil || len(n.Rhs) != 1 || len(n.Lhs) == 0 {
+			return d
+		}
+		ident, ok := n.Lhs[0].(*ast.Ident)
+		if !ok {
+			return d
+		}
+		delete(d.derived, ident.Name)
+		if call, ok := n.Rhs[0].(*ast.CallExpr); ok {
+			if pkg, name, ok := resolveSelector(d.ctx.ImportMap, call.Fun); ok && pkg == "context" && (name == "WithTimeout" || name == "WithDeadline") {
+				d.derived[ident.Name] = call
+			}
+		}
+
+	case *ast.CallExpr:
+		if len(d.derived) == 0 || !d.schedulesActivity(n) {
+			return d
+		}
+		for _, arg := range n.Args {
+			ident, ok := arg.(*ast.Ident)
+			if !ok || d.derived[ident.Name] == nil {
+				continue
+			}
+			d.report(d.derived[ident.Name])
+		}
+	}
+	return d
+}
+
+// schedulesActivity reports whether call is workflow.ExecuteActivity and
+// friends, or a direct call of a function registered as an activity.
+func (d *ContextMisuseDetector) schedulesActivity(call *ast.CallExpr) bool {
+	if pkg, name, ok := resolveSelector(d.ctx.ImportMap, call.Fun); ok && isWorkflowPackage(pkg) && activitySchedulers[name] {
+		return true
+	}
+	ident, ok := call.Fun.(*ast.Ident)
+	return ok && d.wr != nil && d.wr.ActivityFuncs[registry.NewFuncID(d.pkgPath, ident.Name)]
+}
+
+func (d *ContextMisuseDetector) report(deadline *ast.CallExpr) {
+	if d.reported[deadline] || !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+		return
+	}
+	d.reported[deadline] = true
+	_, name, _ := resolveSelector(d.ctx.ImportMap, deadline.Fun)
+	pos := d.ctx.Fset.Position(deadline.Pos())
+	d.issues = append(d.issues, Issue{
+		File:     d.ctx.File,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Rule:     "ContextMisuse",
+		Severity: "warning",
+		Message:  "Detected context." + name + "() bounding an activity in workflow code. Its deadline runs on the worker's wall clock and isn't replayed; set StartToCloseTimeout (or ScheduleToCloseTimeout) in workflow.WithActivityOptions instead.",
+		Func:     d.currFunc,
+	})
+}
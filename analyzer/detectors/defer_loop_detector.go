@@ -0,0 +1,119 @@
+package detectors
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+	"github.com/afony10/cadence-workflow-linter/config"
+)
+
+// DeferLoopDetector flags defer statements inside loops of workflow code. The
+// deferred calls pile up across iterations and only run when the function returns.
+type DeferLoopDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	issues   []Issue
+	reported map[token.Pos]bool // defers already reported via an outer loop
+
+	functionSet      map[string]map[string]config.FunctionRule // function rules by import path and name
+	functionPatterns []functionPattern                         // function rules using package_regex/functions_regex
+}
+
+// NewDeferLoopDetector returns a detector that names the function rule a
+// deferred call matches, if any, in its message.
+func NewDeferLoopDetector(rules []config.FunctionRule) *DeferLoopDetector {
+	d := &DeferLoopDetector{issues: []Issue{}, reported: map[token.Pos]bool{}, functionSet: map[string]map[string]config.FunctionRule{}}
+	for _, r := range rules {
+		if cp, ok := newCallPattern(r.Package, r.PackageRegex, r.Functions, r.FunctionsRegex); ok {
+			d.functionPatterns = append(d.functionPatterns, functionPattern{cp, r})
+			continue
+		}
+		if r.PackageRegex != "" || r.FunctionsRegex != "" {
+			continue
+		}
+		if d.functionSet[r.Package] == nil {
+			d.functionSet[r.Package] = map[string]config.FunctionRule{}
+		}
+		for _, f := range r.Functions {
+			d.functionSet[r.Package][f] = r
+		}
+	}
+	return d
+}
+
+func (d *DeferLoopDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *DeferLoopDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *DeferLoopDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *DeferLoopDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *DeferLoopDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+
+	case *ast.ForStmt:
+		d.checkLoopBody(n.Body)
+
+	case *ast.RangeStmt:
+		d.checkLoopBody(n.Body)
+	}
+	return d
+}
+
+func (d *DeferLoopDetector) checkLoopBody(body *ast.BlockStmt) {
+	if body == nil || !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+		return
+	}
+	ast.Inspect(body, func(m ast.Node) bool {
+		switch s := m.(type) {
+		case *ast.FuncLit:
+			return false // a defer inside a closure runs when the closure returns
+		case *ast.DeferStmt:
+			if d.reported[s.Defer] {
+				return true
+			}
+			d.reported[s.Defer] = true
+			d.report(s)
+		}
+		return true
+	})
+}
+
+func (d *DeferLoopDetector) report(s *ast.DeferStmt) {
+	message := "Detected defer inside a loop in workflow. Deferred calls accumulate across iterations and only run when the function returns; move the loop body into a helper function or make the call explicitly."
+	if rule, ok := d.functionRule(s.Call.Fun); ok {
+		message += " The deferred call " + types.ExprString(s.Call.Fun) + "() is itself reported as " + rule.Rule + "."
+	}
+
+	pos := d.ctx.Fset.Position(s.Defer)
+	d.issues = append(d.issues, Issue{
+		File:     d.ctx.File,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Rule:     "DeferInLoop",
+		Severity: "info",
+		Message:  message,
+		Func:     d.currFunc,
+	})
+}
+
+// functionRule returns the function rule matching the called function, if any.
+func (d *DeferLoopDetector) functionRule(fun ast.Expr) (config.FunctionRule, bool) {
+	pkg, name, ok := resolveSelector(d.ctx.ImportMap, fun)
+	if !ok {
+		return config.FunctionRule{}, false
+	}
+	if rule, ok := d.functionSet[pkg][name]; ok {
+		return rule, true
+	}
+	for _, p := range d.functionPatterns {
+		if p.matches(pkg, name) {
+			return p.rule, true
+		}
+	}
+	return config.FunctionRule{}, false
+}
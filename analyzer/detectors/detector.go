@@ -11,10 +11,48 @@ type Issue struct {
 	Line      int      `json:"line" yaml:"line"`
 	Column    int      `json:"column" yaml:"column"`
 	Rule      string   `json:"rule" yaml:"rule"`
+	Category  string   `json:"category,omitempty" yaml:"category,omitempty"` // see RuleMeta
 	Severity  string   `json:"severity" yaml:"severity"`
 	Message   string   `json:"message" yaml:"message"`
 	Func      string   `json:"func,omitempty" yaml:"func,omitempty"`           // function where the issue occurs
 	CallStack []string `json:"callstack,omitempty" yaml:"callstack,omitempty"` // optional path from workflow
+
+	SuggestedFix *SuggestedFix `json:"suggested_fix,omitempty" yaml:"suggested_fix,omitempty"` // optional mechanical fix
+}
+
+// SuggestedFix is a mechanical rewrite that resolves an issue.
+type SuggestedFix struct {
+	Message    string     `json:"message" yaml:"message"`
+	Confidence string     `json:"confidence" yaml:"confidence"` // "high" fixes may be applied automatically
+	Edits      []TextEdit `json:"edits" yaml:"edits"`
+}
+
+// Fix confidence levels. Only high-confidence fixes are applied by --fix-apply.
+const (
+	FixConfidenceHigh = "high"
+	FixConfidenceLow  = "low"
+)
+
+// TextEdit replaces the byte range [Start, End) of File with NewText.
+type TextEdit struct {
+	File    string `json:"file" yaml:"file"`
+	Start   int    `json:"start" yaml:"start"` // byte offset, inclusive
+	End     int    `json:"end" yaml:"end"`     // byte offset, exclusive
+	NewText string `json:"new_text" yaml:"new_text"`
+}
+
+// SeverityRank orders severities from least (info) to most (error) severe.
+// Unknown severities rank below info.
+func SeverityRank(severity string) int {
+	switch severity {
+	case "error":
+		return 3
+	case "warning":
+		return 2
+	case "info":
+		return 1
+	}
+	return 0
 }
 
 type WorkflowAware interface {
@@ -0,0 +1,66 @@
+package detectors
+
+import (
+	"go/ast"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// DirectActivityCallDetector flags workflow code calling an activity function
+// directly, which runs it inline on the workflow thread instead of scheduling
+// it through workflow.ExecuteActivity.
+type DirectActivityCallDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	issues   []Issue
+}
+
+func NewDirectActivityCallDetector() *DirectActivityCallDetector {
+	return &DirectActivityCallDetector{issues: []Issue{}}
+}
+
+func (d *DirectActivityCallDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) {
+	d.wr = reg
+}
+func (d *DirectActivityCallDetector) SetFileContext(ctx FileContext) { d.ctx = ctx }
+func (d *DirectActivityCallDetector) SetPackagePath(pkgPath string)  { d.pkgPath = pkgPath }
+func (d *DirectActivityCallDetector) Issues() []Issue                { return d.issues }
+
+func (d *DirectActivityCallDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+
+	case *ast.CallExpr:
+		id, ok := d.callee(n.Fun)
+		if !ok || d.wr == nil || !d.wr.ActivityFuncs[id] || !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+			return d
+		}
+		name := id.LocalName()
+		pos := d.ctx.Fset.Position(n.Pos())
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "DirectActivityCall",
+			Severity: "error",
+			Message:  "Detected direct call of activity " + name + " in workflow. It runs inline on the workflow thread and isn't recorded in history; use workflow.ExecuteActivity(ctx, " + name + ", ...) instead.",
+			Func:     d.currFunc,
+		})
+	}
+	return d
+}
+
+// callee returns the canonical name of a call to a package-level function,
+// either local (f(...)) or imported (pkg.F(...)).
+func (d *DirectActivityCallDetector) callee(fun ast.Expr) (registry.FuncID, bool) {
+	if ident, ok := fun.(*ast.Ident); ok {
+		return registry.NewFuncID(d.pkgPath, ident.Name), true
+	}
+	if pkg, name, ok := resolveSelector(d.ctx.ImportMap, fun); ok {
+		return registry.NewFuncID(pkg, name), true
+	}
+	return registry.FuncID{}, false
+}
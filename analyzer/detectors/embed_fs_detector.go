@@ -0,0 +1,101 @@
+package detectors
+
+import (
+	"go/ast"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// embedFSReads are the embed.FS methods that read the embedded files.
+var embedFSReads = map[string]bool{"ReadFile": true, "ReadDir": true, "Open": true}
+
+// EmbedFSDetector flags reads through embed.FS values in workflows. Calls like
+// fs.ReadFile(content, ...) are covered by the io/fs rules; this catches the
+// method form, content.ReadFile(...), which no package rule can match.
+type EmbedFSDetector struct {
+	ctx       FileContext
+	wr        *registry.WorkflowRegistry
+	currFunc  string
+	pkgPath   string
+	issues    []Issue
+	pkgVars   map[string]bool // package-level embed.FS variables, from every file of the package
+	localVars map[string]bool // embed.FS variables of the current function
+}
+
+func NewEmbedFSDetector() *EmbedFSDetector {
+	return &EmbedFSDetector{issues: []Issue{}}
+}
+
+func (d *EmbedFSDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *EmbedFSDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *EmbedFSDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *EmbedFSDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *EmbedFSDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.File:
+		d.pkgVars = map[string]bool{}
+		d.localVars = map[string]bool{}
+		if d.wr != nil {
+			for name := range d.wr.VarDecls[d.pkgPath] {
+				if isPackageEmbedFS(d.wr, d.pkgPath, name) {
+					d.pkgVars[name] = true
+				}
+			}
+		}
+
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+		d.localVars = map[string]bool{}
+		if n.Type.Params != nil {
+			for _, field := range n.Type.Params.List {
+				if isEmbedFS(qualifiedType(d.ctx.ImportMap, field.Type)) {
+					for _, name := range field.Names {
+						d.localVars[name.Name] = true
+					}
+				}
+			}
+		}
+
+	case *ast.ValueSpec:
+		if n.Type != nil && isEmbedFS(qualifiedType(d.ctx.ImportMap, n.Type)) {
+			for _, name := range n.Names {
+				d.localVars[name.Name] = true
+			}
+		}
+
+	case *ast.CallExpr:
+		sel, ok := n.Fun.(*ast.SelectorExpr)
+		if !ok || !embedFSReads[sel.Sel.Name] {
+			return d
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok || !(d.localVars[ident.Name] || d.pkgVars[ident.Name]) {
+			return d
+		}
+		if !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+			return d
+		}
+		pos := d.ctx.Fset.Position(sel.Sel.Pos())
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "IOCalls",
+			Severity: "warning",
+			Message:  "Detected embed.FS." + sel.Sel.Name + "() on " + ident.Name + " in workflow. Filesystem reads (including embed.FS) belong in activities or should be loaded before the workflow starts.",
+			Func:     d.currFunc,
+		})
+	}
+	return d
+}
+
+func isEmbedFS(typeName string) bool {
+	return typeName == "embed.FS"
+}
+
+// isPackageEmbedFS reports whether name is a package-level embed.FS of pkgPath.
+func isPackageEmbedFS(wr *registry.WorkflowRegistry, pkgPath, name string) bool {
+	decl, ok := wr.VarDecls[pkgPath][name]
+	return ok && decl.Type != nil && isEmbedFS(qualifiedType(decl.ImportMap, decl.Type))
+}
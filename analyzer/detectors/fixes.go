@@ -0,0 +1,100 @@
+package detectors
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// Per-rule fixers. Each returns nil when the rewrite isn't mechanical, e.g. when
+// the enclosing function has no workflow.Context parameter to thread through.
+
+// workflowContextParam returns the name of fn's workflow.Context parameter, if any.
+func workflowContextParam(importMap map[string]string, fn *ast.FuncDecl) string {
+	if fn.Type.Params == nil {
+		return ""
+	}
+	for _, field := range fn.Type.Params.List {
+		pkg, name, ok := resolveSelector(importMap, field.Type)
+		if ok && isWorkflowPackage(pkg) && name == "Context" && len(field.Names) > 0 && field.Names[0].Name != "_" {
+			return field.Names[0].Name
+		}
+	}
+	return ""
+}
+
+// workflowAlias returns the identifier the file uses for the workflow package.
+// When it is imported under several names, "workflow" wins, else the first
+// name in sorted order, so fixes don't depend on map iteration order.
+func workflowAlias(importMap map[string]string) string {
+	if isWorkflowPackage(importMap["workflow"]) {
+		return "workflow"
+	}
+	alias := ""
+	for a, path := range importMap {
+		if isWorkflowPackage(path) && (alias == "" || a < alias) {
+			alias = a
+		}
+	}
+	return alias
+}
+
+func textEdit(ctx FileContext, start, end token.Pos, newText string) TextEdit {
+	return TextEdit{
+		File:    ctx.File,
+		Start:   ctx.Fset.Position(start).Offset,
+		End:     ctx.Fset.Position(end).Offset,
+		NewText: newText,
+	}
+}
+
+// fixTimeNow rewrites time.Now() to workflow.Now(ctx).
+func fixTimeNow(ctx FileContext, call *ast.CallExpr, ctxName string) *SuggestedFix {
+	wf := workflowAlias(ctx.ImportMap)
+	if call == nil || ctxName == "" || wf == "" || len(call.Args) != 0 {
+		return nil
+	}
+	return &SuggestedFix{
+		Message:    "Replace time.Now() with " + wf + ".Now(" + ctxName + ")",
+		Confidence: FixConfidenceHigh,
+		Edits:      []TextEdit{textEdit(ctx, call.Pos(), call.End(), wf+".Now("+ctxName+")")},
+	}
+}
+
+// fixPrintln rewrites fmt.Println("msg") to workflow.GetLogger(ctx).Info("msg").
+// Only a single string literal argument is rewritten, since the logger's
+// Info takes a message plus structured fields rather than arbitrary values.
+func fixPrintln(ctx FileContext, call *ast.CallExpr, ctxName string) *SuggestedFix {
+	wf := workflowAlias(ctx.ImportMap)
+	if call == nil || ctxName == "" || wf == "" || len(call.Args) != 1 {
+		return nil
+	}
+	if lit, ok := call.Args[0].(*ast.BasicLit); !ok || lit.Kind != token.STRING {
+		return nil
+	}
+	logger := wf + ".GetLogger(" + ctxName + ").Info"
+	return &SuggestedFix{
+		Message:    "Replace fmt call with " + logger,
+		Confidence: FixConfidenceLow, // log output moves from stdout to the workflow logger
+		Edits:      []TextEdit{textEdit(ctx, call.Fun.Pos(), call.Fun.End(), logger)},
+	}
+}
+
+// fixGoStmt rewrites `go func() { ... }()` to `workflow.Go(ctx, func(ctx workflow.Context) { ... })`.
+func fixGoStmt(ctx FileContext, stmt *ast.GoStmt, ctxName string) *SuggestedFix {
+	wf := workflowAlias(ctx.ImportMap)
+	if ctxName == "" || wf == "" || len(stmt.Call.Args) != 0 {
+		return nil
+	}
+	lit, ok := stmt.Call.Fun.(*ast.FuncLit)
+	if !ok || lit.Type.Params.NumFields() != 0 || lit.Type.Results.NumFields() != 0 {
+		return nil
+	}
+	return &SuggestedFix{
+		Message:    "Replace native goroutine with " + wf + ".Go",
+		Confidence: FixConfidenceLow, // the closure body may still use native concurrency
+		Edits: []TextEdit{
+			textEdit(ctx, stmt.Go, lit.Body.Lbrace, wf+".Go("+ctxName+", func("+ctxName+" "+wf+".Context) "),
+			textEdit(ctx, stmt.Call.Lparen, stmt.Call.Rparen+1, ")"),
+		},
+	}
+}
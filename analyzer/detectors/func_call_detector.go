@@ -3,7 +3,8 @@ package detectors
 import (
 	"fmt"
 	"go/ast"
-	"strings"
+	"regexp"
+	"sort"
 
 	"github.com/afony10/cadence-workflow-linter/analyzer/modutils"
 	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
@@ -11,23 +12,97 @@ import (
 )
 
 type FuncCallDetector struct {
-	rules            []config.FunctionRule
-	externalRules    []config.ExternalPackageRule
-	safeExternalPkgs []string
-	moduleInfo       *modutils.ModuleInfo // For hybrid package classification
-	ctx              FileContext
-	wr               *registry.WorkflowRegistry
-	currFunc         string
-	pkgPath          string // package path for the current file
-	issues           []Issue
-	functionSet      map[string]map[string]config.FunctionRule        // importPath -> funcName -> rule
-	externalFuncSet  map[string]map[string]config.ExternalPackageRule // external importPath -> funcName -> rule
+	rules             []config.FunctionRule
+	externalRules     []config.ExternalPackageRule
+	safeExternalPkgs  []string
+	moduleInfo        *modutils.ModuleInfo // For hybrid package classification
+	ctx               FileContext
+	wr                *registry.WorkflowRegistry
+	currFunc          string
+	pkgPath           string                              // package path for the current file
+	ctxParam          string                              // name of the current function's workflow.Context parameter
+	calls             map[*ast.SelectorExpr]*ast.CallExpr // selector -> enclosing call, for fixes
+	issues            []Issue
+	functionSet       map[string]map[string]config.FunctionRule        // importPath -> funcName -> rule
+	externalFuncSet   map[string]map[string]config.ExternalPackageRule // external importPath -> funcName -> rule
+	functionPatterns  []functionPattern                                // function rules using package_regex/functions_regex
+	externalPatterns  []externalPattern                                // external rules using package_regex/functions_regex
+	unknownExternal   string                                           // UnknownExternalCall severity, or "off"
+	classifier        PackageClassifier                                // optional override of the default classification
+	defaultClassifier *DefaultClassifier
+}
+
+// callPattern matches calls for a rule that uses package_regex or
+// functions_regex. Without any function criteria it matches every function
+// of the matched packages.
+type callPattern struct {
+	pkg    string
+	pkgRe  *regexp.Regexp
+	funcs  map[string]bool
+	funcRe *regexp.Regexp
+}
+
+type functionPattern struct {
+	callPattern
+	rule config.FunctionRule
+}
+
+type externalPattern struct {
+	callPattern
+	rule config.ExternalPackageRule
+}
+
+// newCallPattern compiles a rule's patterns. ok is false for rules without
+// regex fields, which use the exact-match maps, and for invalid patterns
+// (config.Validate rejects those).
+func newCallPattern(pkg, pkgRegex string, funcs []string, funcsRegex string) (callPattern, bool) {
+	if pkgRegex == "" && funcsRegex == "" {
+		return callPattern{}, false
+	}
+	p := callPattern{pkg: pkg, funcs: map[string]bool{}}
+	for _, f := range funcs {
+		p.funcs[f] = true
+	}
+	var err error
+	if pkgRegex != "" {
+		if p.pkgRe, err = config.CompilePattern(pkgRegex); err != nil {
+			return callPattern{}, false
+		}
+	}
+	if funcsRegex != "" {
+		if p.funcRe, err = config.CompilePattern(funcsRegex); err != nil {
+			return callPattern{}, false
+		}
+	}
+	return p, true
+}
+
+func (p callPattern) matches(importPath, funcName string) bool {
+	if p.pkgRe != nil {
+		if !p.pkgRe.MatchString(importPath) {
+			return false
+		}
+	} else if p.pkg != importPath {
+		return false
+	}
+	if len(p.funcs) == 0 && p.funcRe == nil {
+		return true
+	}
+	return p.funcs[funcName] || (p.funcRe != nil && p.funcRe.MatchString(funcName))
 }
 
 func NewFuncCallDetector(rules []config.FunctionRule, externalRules []config.ExternalPackageRule, safeExternalPkgs []string, moduleInfo *modutils.ModuleInfo) *FuncCallDetector {
 	// Build regular function rules map
 	fnSet := map[string]map[string]config.FunctionRule{}
+	var fnPatterns []functionPattern
 	for _, r := range rules {
+		if cp, ok := newCallPattern(r.Package, r.PackageRegex, r.Functions, r.FunctionsRegex); ok {
+			fnPatterns = append(fnPatterns, functionPattern{cp, r})
+			continue
+		}
+		if r.PackageRegex != "" || r.FunctionsRegex != "" {
+			continue
+		}
 		p := r.Package
 		if _, ok := fnSet[p]; !ok {
 			fnSet[p] = map[string]config.FunctionRule{}
@@ -39,7 +114,15 @@ func NewFuncCallDetector(rules []config.FunctionRule, externalRules []config.Ext
 
 	// Build external package rules map
 	extFnSet := map[string]map[string]config.ExternalPackageRule{}
+	var extPatterns []externalPattern
 	for _, r := range externalRules {
+		if cp, ok := newCallPattern(r.Package, r.PackageRegex, r.Functions, r.FunctionsRegex); ok {
+			extPatterns = append(extPatterns, externalPattern{cp, r})
+			continue
+		}
+		if r.PackageRegex != "" || r.FunctionsRegex != "" {
+			continue
+		}
 		p := r.Package
 		if _, ok := extFnSet[p]; !ok {
 			extFnSet[p] = map[string]config.ExternalPackageRule{}
@@ -49,6 +132,11 @@ func NewFuncCallDetector(rules []config.FunctionRule, externalRules []config.Ext
 		}
 	}
 
+	rulePkgs := make(map[string]bool, len(extFnSet))
+	for p := range extFnSet {
+		rulePkgs[p] = true
+	}
+
 	return &FuncCallDetector{
 		rules:            rules,
 		externalRules:    externalRules,
@@ -57,6 +145,23 @@ func NewFuncCallDetector(rules []config.FunctionRule, externalRules []config.Ext
 		issues:           []Issue{},
 		functionSet:      fnSet,
 		externalFuncSet:  extFnSet,
+		functionPatterns: fnPatterns,
+		externalPatterns: extPatterns,
+		calls:            map[*ast.SelectorExpr]*ast.CallExpr{},
+		unknownExternal:  "info",
+
+		defaultClassifier: &DefaultClassifier{ModuleInfo: moduleInfo, SafePackages: safeExternalPkgs, RulePackages: rulePkgs},
+	}
+}
+
+// SetPackageClassifier installs a classifier consulted before the default one.
+func (d *FuncCallDetector) SetPackageClassifier(c PackageClassifier) { d.classifier = c }
+
+// SetUnknownExternalCall sets the severity of UnknownExternalCall issues;
+// "off" disables the check. An empty value keeps the default ("info").
+func (d *FuncCallDetector) SetUnknownExternalCall(severity string) {
+	if severity != "" {
+		d.unknownExternal = severity
 	}
 }
 
@@ -73,7 +178,32 @@ func (d *FuncCallDetector) Visit(node ast.Node) ast.Visitor {
 	switch n := node.(type) {
 	case *ast.FuncDecl:
 		if n.Name != nil {
-			d.currFunc = n.Name.Name
+			d.currFunc = registry.FuncDeclName(n)
+		}
+		d.ctxParam = workflowContextParam(d.ctx.ImportMap, n)
+
+	case *ast.CallExpr:
+		if sel, ok := n.Fun.(*ast.SelectorExpr); ok {
+			d.calls[sel] = n
+		}
+		// rand.Seed(time.Now().UnixNano()) is reported once, under the
+		// configured math/rand rule, instead of separate time and rand findings.
+		if sel, rule, ok := d.timeSeededRand(n); ok {
+			d.createIssueIfInWorkflow(sel, rule.Rule, rule.Severity, d.ruleMessage(
+				"Detected rand."+sel.Sel.Name+"() seeded from time.Now() in workflow. The seed differs on every replay and the random sequence is nondeterministic; use workflow.SideEffect to generate random values.",
+				rule.MessageTemplate, "math/rand", sel), nil)
+			return nil
+		}
+		// time.Now().UnixNano() and friends, typically used as IDs, replace
+		// the configured time.Now finding with a more specific message.
+		if now, unit, ok := d.timeNowUnix(n); ok {
+			rule := d.functionSet["time"]["Now"]
+			d.calls[now.Fun.(*ast.SelectorExpr)] = now
+			d.createIssueIfInWorkflow(now.Fun.(*ast.SelectorExpr), rule.Rule, rule.Severity, d.ruleMessage(
+				"Detected time.Now()."+unit+"() in workflow. Clock-derived values, often used as IDs or seeds, differ on every replay; use workflow.Now(ctx)."+unit+"() for timestamps, or workflow.GetInfo(ctx).WorkflowExecution.RunID or workflow.SideEffect for unique IDs.",
+				rule.MessageTemplate, "time", now.Fun.(*ast.SelectorExpr)),
+				d.suggestFix("time", "Now", now.Fun.(*ast.SelectorExpr)))
+			return nil
 		}
 
 	case *ast.SelectorExpr:
@@ -92,7 +222,16 @@ func (d *FuncCallDetector) Visit(node ast.Node) ast.Visitor {
 		// Check regular function call rules first
 		if ruleMap, ok := d.functionSet[importPath]; ok {
 			if rule, ok := ruleMap[funcName]; ok {
-				d.createIssueIfInWorkflow(n, rule.Rule, rule.Severity, strings.ReplaceAll(rule.Message, "%FUNC%", funcName))
+				d.createIssueIfInWorkflow(n, rule.Rule, rule.Severity, d.ruleMessage(rule.Message, rule.MessageTemplate, importPath, n), d.suggestFix(importPath, funcName, n))
+				return d
+			}
+		}
+		// Patterns can cover whole packages, so they only match calls, not
+		// type or constant references like pkg.Config{}.
+		_, isCall := d.calls[n]
+		for _, p := range d.functionPatterns {
+			if isCall && p.matches(importPath, funcName) {
+				d.createIssueIfInWorkflow(n, p.rule.Rule, p.rule.Severity, d.ruleMessage(p.rule.Message, p.rule.MessageTemplate, importPath, n), d.suggestFix(importPath, funcName, n))
 				return d
 			}
 		}
@@ -100,27 +239,31 @@ func (d *FuncCallDetector) Visit(node ast.Node) ast.Visitor {
 		// Check external package rules
 		if extRuleMap, ok := d.externalFuncSet[importPath]; ok {
 			if extRule, ok := extRuleMap[funcName]; ok {
-				d.createIssueIfInWorkflow(n, extRule.Rule, extRule.Severity, strings.ReplaceAll(extRule.Message, "%FUNC%", funcName))
+				d.createIssueIfInWorkflow(n, extRule.Rule, extRule.Severity, d.ruleMessage(extRule.Message, extRule.MessageTemplate, importPath, n), nil)
+				return d
+			}
+		}
+		for _, p := range d.externalPatterns {
+			if isCall && p.matches(importPath, funcName) {
+				d.createIssueIfInWorkflow(n, p.rule.Rule, p.rule.Severity, d.ruleMessage(p.rule.Message, p.rule.MessageTemplate, importPath, n), nil)
 				return d
 			}
 		}
 
-		// Check if it's a safe external package (no issue needed)
-		if d.isSafeExternalPackage(importPath) {
+		if d.unknownExternal == "off" {
 			return d
 		}
 
-		// Check if it's an unknown external package (not stdlib, not project internal)
-		if d.isUnknownExternalPackage(importPath) {
-			canonicalCurrentFunc := d.pkgPath + "." + d.currFunc
-			if d.wr != nil && d.wr.IsWorkflowReachable(canonicalCurrentFunc) {
+		// Check if it's an unknown external package (not stdlib, not project internal, not safe)
+		if d.classify(importPath) == PackageUnknownExternal {
+			if inWorkflow(d.wr, d.pkgPath, d.currFunc) {
 				pos := d.ctx.Fset.Position(n.Sel.Pos())
 				d.issues = append(d.issues, Issue{
 					File:     d.ctx.File,
 					Line:     pos.Line,
 					Column:   pos.Column,
 					Rule:     "UnknownExternalCall",
-					Severity: "info",
+					Severity: d.unknownExternal,
 					Message:  fmt.Sprintf("Call to unknown external package %s.%s() - please verify it's workflow-safe", importPath, funcName),
 					Func:     d.currFunc,
 				})
@@ -130,15 +273,96 @@ func (d *FuncCallDetector) Visit(node ast.Node) ast.Visitor {
 	return d
 }
 
+// timeSeededRand reports whether call is rand.Seed/rand.NewSource with an
+// argument derived from time.Now() while a rule covers math/rand, returning
+// that rule: the one for the called function if configured, else the one for
+// the first configured math/rand function by name.
+func (d *FuncCallDetector) timeSeededRand(call *ast.CallExpr) (*ast.SelectorExpr, config.FunctionRule, bool) {
+	pkg, name, ok := resolveSelector(d.ctx.ImportMap, call.Fun)
+	if !ok || pkg != "math/rand" || (name != "Seed" && name != "NewSource") {
+		return nil, config.FunctionRule{}, false
+	}
+	randRules := d.functionSet["math/rand"]
+	rule, ok := randRules[name]
+	if !ok {
+		names := make([]string, 0, len(randRules))
+		for fn := range randRules {
+			names = append(names, fn)
+		}
+		if len(names) == 0 {
+			return nil, config.FunctionRule{}, false
+		}
+		sort.Strings(names)
+		rule = randRules[names[0]]
+	}
+	fromClock := false
+	for _, arg := range call.Args {
+		ast.Inspect(arg, func(m ast.Node) bool {
+			if inner, ok := m.(*ast.CallExpr); ok {
+				if p, f, ok := resolveSelector(d.ctx.ImportMap, inner.Fun); ok && p == "time" && f == "Now" {
+					fromClock = true
+				}
+			}
+			return !fromClock
+		})
+	}
+	if !fromClock {
+		return nil, config.FunctionRule{}, false
+	}
+	return call.Fun.(*ast.SelectorExpr), rule, true
+}
+
+// timeNowUnix reports whether call is time.Now().Unix(), UnixNano(),
+// UnixMicro() or UnixMilli() while a rule covers time.Now, returning the
+// time.Now() call and the method.
+func (d *FuncCallDetector) timeNowUnix(call *ast.CallExpr) (*ast.CallExpr, string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, "", false
+	}
+	switch sel.Sel.Name {
+	case "Unix", "UnixNano", "UnixMicro", "UnixMilli":
+	default:
+		return nil, "", false
+	}
+	now, ok := sel.X.(*ast.CallExpr)
+	if _, configured := d.functionSet["time"]["Now"]; !ok || !configured {
+		return nil, "", false
+	}
+	if pkg, name, ok := resolveSelector(d.ctx.ImportMap, now.Fun); !ok || pkg != "time" || name != "Now" {
+		return nil, "", false
+	}
+	return now, sel.Sel.Name, true
+}
+
+// ruleMessage renders a configured rule's message for a call of node, then its
+// message_template (if any) with the rendered message as %MESSAGE%.
+func (d *FuncCallDetector) ruleMessage(message, template, importPath string, node *ast.SelectorExpr) string {
+	vars := MessageVars{
+		Func: node.Sel.Name,
+		Pkg:  importPath,
+		File: d.ctx.File,
+		Line: d.ctx.Fset.Position(node.Sel.Pos()).Line,
+	}
+	vars.Message = RenderMessage(message, vars)
+	if template == "" {
+		return vars.Message
+	}
+	return RenderMessage(template, vars)
+}
+
 // Helper method to create issue if in workflow context
-func (d *FuncCallDetector) createIssueIfInWorkflow(node *ast.SelectorExpr, rule, severity, message string) {
-	// Check if we're in a workflow context using canonical function name
-	canonicalCurrentFunc := d.pkgPath + "." + d.currFunc
-	if d.wr != nil && d.wr.IsWorkflowReachable(canonicalCurrentFunc) {
+func (d *FuncCallDetector) createIssueIfInWorkflow(node *ast.SelectorExpr, rule, severity, message string, fix *SuggestedFix) {
+	// Check if we're in a workflow context using the canonical function ID
+	current := registry.NewFuncID(d.pkgPath, d.currFunc)
+	if d.wr != nil && d.wr.IsWorkflowReachable(current) {
 		pos := d.ctx.Fset.Position(node.Sel.Pos())
 
 		// Try to get call stack for better debugging
-		callStack := d.wr.CallPathTo(canonicalCurrentFunc)
+		var callStack []string
+		for _, id := range d.wr.CallPathTo(current) {
+			callStack = append(callStack, id.String())
+		}
 
 		d.issues = append(d.issues, Issue{
 			File:      d.ctx.File,
@@ -149,84 +373,29 @@ func (d *FuncCallDetector) createIssueIfInWorkflow(node *ast.SelectorExpr, rule,
 			Message:   message,
 			Func:      d.currFunc,
 			CallStack: callStack,
+
+			SuggestedFix: fix,
 		})
 	}
 }
 
-// Helper method to check if a package is in the safe external packages list
-func (d *FuncCallDetector) isSafeExternalPackage(importPath string) bool {
-	for _, safePkg := range d.safeExternalPkgs {
-		if importPath == safePkg || strings.HasPrefix(importPath, safePkg+"/") {
-			return true
-		}
+// suggestFix returns a mechanical fix for well-known calls, if one applies.
+func (d *FuncCallDetector) suggestFix(importPath, funcName string, node *ast.SelectorExpr) *SuggestedFix {
+	switch importPath + "." + funcName {
+	case "time.Now":
+		return fixTimeNow(d.ctx, d.calls[node], d.ctxParam)
+	case "fmt.Println", "fmt.Print":
+		return fixPrintln(d.ctx, d.calls[node], d.ctxParam)
 	}
-	return false
+	return nil
 }
 
-// Helper method to check if a package is an unknown external package
-func (d *FuncCallDetector) isUnknownExternalPackage(importPath string) bool {
-	// Skip standard library packages (no dots, or golang.org/x/)
-	if !strings.Contains(importPath, ".") || strings.HasPrefix(importPath, "golang.org/x/") {
-		return false
-	}
-
-	// Skip Cadence framework packages (these are expected and safe)
-	if strings.HasPrefix(importPath, "go.uber.org/cadence") {
-		return false
-	}
-
-	// Skip if it's in our known external rules
-	if _, exists := d.externalFuncSet[importPath]; exists {
-		return false
-	}
-
-	// Skip if it's a safe external package
-	if d.isSafeExternalPackage(importPath) {
-		return false
-	}
-
-	// Skip if it appears to be project-internal using hybrid approach
-	if d.isInternalPackage(importPath) {
-		return false
-	}
-
-	// Skip testdata packages
-	if strings.HasPrefix(importPath, "testdata/") || strings.HasPrefix(importPath, "example.com/linttest/") {
-		return false
-	}
-
-	// If we get here, it's likely an external third-party package we don't know about
-	return true
-}
-
-// isInternalPackage determines if a package is internal using hybrid approach
-func (d *FuncCallDetector) isInternalPackage(importPath string) bool {
-	// Solution 1: Use go.mod information if available
-	if d.moduleInfo != nil {
-		// Check if it's an internal package according to go.mod
-		if d.moduleInfo.IsInternalPackage(importPath) {
-			return true
-		}
-
-		// Check if it's replaced by a local path (also considered internal)
-		if isReplaced, newPath := d.moduleInfo.IsReplacedPackage(importPath); isReplaced {
-			// If replaced with local path, consider it internal
-			if !strings.Contains(newPath, "/") || strings.HasPrefix(newPath, "./") || strings.HasPrefix(newPath, "../") {
-				return true
-			}
+// classify applies the custom classifier, falling back to the default one.
+func (d *FuncCallDetector) classify(importPath string) PackageClass {
+	if d.classifier != nil {
+		if class := d.classifier.Classify(importPath); class != PackageUndecided {
+			return class
 		}
 	}
-
-	// Solution 3: Enhanced heuristics as fallback
-	// Hardcoded project path as fallback when go.mod is not available
-	if strings.HasPrefix(importPath, "github.com/afony10/cadence-workflow-linter") {
-		return true
-	}
-
-	// Testdata packages are considered internal for testing purposes
-	if strings.HasPrefix(importPath, "testdata/") || strings.HasPrefix(importPath, "example.com/linttest/") {
-		return true
-	}
-
-	return false
+	return d.defaultClassifier.Classify(importPath)
 }
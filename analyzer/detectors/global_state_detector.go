@@ -0,0 +1,158 @@
+package detectors
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// GlobalStateDetector flags package-level variables mutated inside loops of
+// workflow code. Package state is shared by every execution on the worker, so
+// a workflow accumulating into it (typically a counter bumped per item) sees
+// different values on replay.
+type GlobalStateDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	issues   []Issue
+	globals  map[string]bool    // package-level vars declared in any file of the package
+	locals   map[string]bool    // names declared in the current function (shadowing globals)
+	reported map[token.Pos]bool // mutations already reported via an outer loop
+}
+
+func NewGlobalStateDetector() *GlobalStateDetector {
+	return &GlobalStateDetector{issues: []Issue{}, reported: map[token.Pos]bool{}}
+}
+
+func (d *GlobalStateDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *GlobalStateDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *GlobalStateDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *GlobalStateDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *GlobalStateDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.File:
+		if d.wr != nil {
+			d.globals = d.wr.PackageVars[d.pkgPath]
+		}
+
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+		d.locals = declaredNames(n)
+
+	case *ast.ForStmt:
+		d.checkLoopBody(n.Body)
+
+	case *ast.RangeStmt:
+		d.checkLoopBody(n.Body)
+	}
+	return d
+}
+
+func (d *GlobalStateDetector) checkLoopBody(body *ast.BlockStmt) {
+	if body == nil || len(d.globals) == 0 || !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+		return
+	}
+	ast.Inspect(body, func(m ast.Node) bool {
+		switch s := m.(type) {
+		case *ast.AssignStmt:
+			if s.Tok == token.DEFINE {
+				return true
+			}
+			for _, lhs := range s.Lhs {
+				d.checkTarget(lhs)
+			}
+		case *ast.IncDecStmt:
+			d.checkTarget(s.X)
+		}
+		return true
+	})
+}
+
+func (d *GlobalStateDetector) checkTarget(expr ast.Expr) {
+	ident := rootIdent(expr)
+	if ident == nil || !d.globals[ident.Name] || d.locals[ident.Name] || d.reported[ident.Pos()] {
+		return
+	}
+	d.reported[ident.Pos()] = true
+	pos := d.ctx.Fset.Position(ident.Pos())
+	d.issues = append(d.issues, Issue{
+		File:     d.ctx.File,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Rule:     "NonDeterminism",
+		Severity: "error",
+		Message:  "Package-level variable " + ident.Name + " is mutated inside a loop in workflow code. Its value is shared with other executions on the worker and differs on replay; keep the state in a local variable or workflow input.",
+		Func:     d.currFunc,
+	})
+}
+
+// rootIdent returns x for x, x.f, x[i] and *x.
+func rootIdent(expr ast.Expr) *ast.Ident {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e
+	case *ast.SelectorExpr:
+		return rootIdent(e.X)
+	case *ast.IndexExpr:
+		return rootIdent(e.X)
+	case *ast.StarExpr:
+		return rootIdent(e.X)
+	case *ast.ParenExpr:
+		return rootIdent(e.X)
+	}
+	return nil
+}
+
+// declaredNames returns the receiver, parameter, result and local variable
+// names of fn. Scoping is ignored: a name declared anywhere counts everywhere.
+func declaredNames(fn *ast.FuncDecl) map[string]bool {
+	names := map[string]bool{}
+	addFields := func(fl *ast.FieldList) {
+		if fl == nil {
+			return
+		}
+		for _, field := range fl.List {
+			for _, name := range field.Names {
+				names[name.Name] = true
+			}
+		}
+	}
+	addFields(fn.Recv)
+	addFields(fn.Type.Params)
+	addFields(fn.Type.Results)
+	if fn.Body == nil {
+		return names
+	}
+	ast.Inspect(fn.Body, func(m ast.Node) bool {
+		switch s := m.(type) {
+		case *ast.AssignStmt:
+			if s.Tok == token.DEFINE {
+				for _, lhs := range s.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						names[ident.Name] = true
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for _, name := range s.Names {
+				names[name.Name] = true
+			}
+		case *ast.RangeStmt:
+			if s.Tok == token.DEFINE {
+				for _, e := range []ast.Expr{s.Key, s.Value} {
+					if ident, ok := e.(*ast.Ident); ok {
+						names[ident.Name] = true
+					}
+				}
+			}
+		case *ast.FuncLit:
+			addFields(s.Type.Params)
+			addFields(s.Type.Results)
+		}
+		return true
+	})
+	return names
+}
@@ -0,0 +1,130 @@
+package detectors
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// GlobalVarDetector flags workflow code reading or assigning package-level
+// variables of its own package. Their values are shared with every other
+// execution on the worker, so a replay can see different state than the
+// original run. Mutations inside loops are left to GlobalStateDetector, which
+// reports them as errors, Do on a package-level sync.Once to
+// SyncPrimitiveDetector (LazyInit) and embed.FS reads to EmbedFSDetector.
+type GlobalVarDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	issues   []Issue
+}
+
+func NewGlobalVarDetector() *GlobalVarDetector {
+	return &GlobalVarDetector{issues: []Issue{}}
+}
+
+func (d *GlobalVarDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *GlobalVarDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *GlobalVarDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *GlobalVarDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *GlobalVarDetector) Visit(node ast.Node) ast.Visitor {
+	fn, ok := node.(*ast.FuncDecl)
+	if !ok {
+		return d
+	}
+	d.currFunc = registry.FuncDeclName(fn)
+	if fn.Body == nil || !inWorkflow(d.wr, d.pkgPath, d.currFunc) || len(d.wr.PackageVars[d.pkgPath]) == 0 {
+		return d
+	}
+	d.checkBody(fn.Body, d.wr.PackageVars[d.pkgPath], declaredNames(fn))
+	return d
+}
+
+func (d *GlobalVarDetector) checkBody(body *ast.BlockStmt, globals, locals map[string]bool) {
+	writes := map[*ast.Ident]bool{}
+	inLoop := map[*ast.Ident]bool{}
+	skip := map[*ast.Ident]bool{}
+	ast.Inspect(body, func(m ast.Node) bool {
+		switch s := m.(type) {
+		case *ast.AssignStmt:
+			if s.Tok != token.DEFINE {
+				for _, lhs := range s.Lhs {
+					if ident := rootIdent(lhs); ident != nil {
+						writes[ident] = true
+					}
+				}
+			}
+		case *ast.IncDecStmt:
+			if ident := rootIdent(s.X); ident != nil {
+				writes[ident] = true
+			}
+		case *ast.CallExpr:
+			// once.Do on a package-level sync.Once is SyncPrimitiveDetector's
+			// LazyInit, and reads through an embed.FS are EmbedFSDetector's IOCalls.
+			if sel, ok := s.Fun.(*ast.SelectorExpr); ok {
+				if ident, ok := sel.X.(*ast.Ident); ok && !locals[ident.Name] {
+					if (sel.Sel.Name == "Do" && isPackageOnce(d.wr, d.pkgPath, ident.Name)) ||
+						(embedFSReads[sel.Sel.Name] && isPackageEmbedFS(d.wr, d.pkgPath, ident.Name)) {
+						skip[ident] = true
+					}
+				}
+			}
+		case *ast.SelectorExpr:
+			skip[s.Sel] = true // field or method name, not a variable
+		case *ast.CompositeLit:
+			// Keys of struct literals are field names; map keys are values.
+			if _, isMap := s.Type.(*ast.MapType); !isMap {
+				for _, elt := range s.Elts {
+					if kv, ok := elt.(*ast.KeyValueExpr); ok {
+						if ident, ok := kv.Key.(*ast.Ident); ok {
+							skip[ident] = true
+						}
+					}
+				}
+			}
+		case *ast.ForStmt:
+			markIdents(s.Body, inLoop)
+		case *ast.RangeStmt:
+			markIdents(s.Body, inLoop)
+		}
+		return true
+	})
+
+	ast.Inspect(body, func(m ast.Node) bool {
+		ident, ok := m.(*ast.Ident)
+		if !ok || skip[ident] || !globals[ident.Name] || locals[ident.Name] {
+			return true
+		}
+		access := "read"
+		if writes[ident] {
+			if inLoop[ident] {
+				return true // GlobalStateDetector's error, from the same PackageVars
+			}
+			access = "assigned"
+		}
+		pos := d.ctx.Fset.Position(ident.Pos())
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "NonDeterminism",
+			Severity: "warning",
+			Message:  "Package-level variable " + ident.Name + " is " + access + " in workflow code. It is shared with other executions on the worker, so its value can differ on replay; pass the value in as workflow input or keep it in a local variable.",
+			Func:     d.currFunc,
+		})
+		return true
+	})
+}
+
+// markIdents adds every identifier under n to set.
+func markIdents(n ast.Node, set map[*ast.Ident]bool) {
+	ast.Inspect(n, func(m ast.Node) bool {
+		if ident, ok := m.(*ast.Ident); ok {
+			set[ident] = true
+		}
+		return true
+	})
+}
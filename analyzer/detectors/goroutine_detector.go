@@ -2,6 +2,8 @@ package detectors
 
 import (
 	"go/ast"
+	"go/token"
+	"strings"
 
 	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
 )
@@ -10,6 +12,9 @@ type GoroutineDetector struct {
 	ctx      FileContext
 	wr       *registry.WorkflowRegistry
 	currFunc string
+	pkgPath  string
+	ctxParam string            // name of the current function's workflow.Context parameter
+	buffers  map[string]string // current function's bytes.Buffer / strings.Builder variables -> type
 	issues   []Issue
 }
 
@@ -19,6 +24,7 @@ func NewGoroutineDetector() *GoroutineDetector {
 
 func (d *GoroutineDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
 func (d *GoroutineDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *GoroutineDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
 func (d *GoroutineDetector) Issues() []Issue                                    { return d.issues }
 
 // Visit implements ast.Visitor
@@ -26,19 +32,202 @@ func (d *GoroutineDetector) Issues() []Issue
 func (d *GoroutineDetector) Visit(node ast.Node) ast.Visitor {
 	switch n := node.(type) {
 	case *ast.FuncDecl:
-		d.currFunc = n.Name.Name
+		d.currFunc = registry.FuncDeclName(n)
+		d.ctxParam = workflowContextParam(d.ctx.ImportMap, n)
+		d.buffers = bufferVars(d.ctx.ImportMap, n)
 
 	case *ast.GoStmt:
+		// goroutines are fine in activities and other non-workflow code
+		if !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+			return d
+		}
 		pos := d.ctx.Fset.Position(n.Go)
+		msg := "Detected goroutine. Use workflow.Go(ctx) inside workflows."
+		if d.capturesWorkflowContext(n.Call) {
+			msg = "Detected goroutine that is handed the workflow.Context. The context is only valid on the workflow's own coroutines; using it from a native goroutine breaks the deterministic scheduler. Use workflow.Go(ctx) inside workflows."
+		}
 		d.issues = append(d.issues, Issue{
 			File:     d.ctx.File,
 			Line:     pos.Line,
 			Column:   pos.Column,
 			Rule:     "Concurrency",
 			Severity: "error",
-			Message:  "Detected goroutine. Use workflow.Go(ctx) inside workflows.",
+			Message:  msg,
 			Func:     d.currFunc,
+
+			SuggestedFix: fixGoStmt(d.ctx, n, d.ctxParam),
 		})
+		d.checkSharedBuffers(n)
 	}
 	return d
 }
+
+// capturesWorkflowContext reports whether the goroutine's call passes the
+// enclosing function's workflow.Context, declares a workflow.Context
+// parameter, or uses the context from inside its closure.
+func (d *GoroutineDetector) capturesWorkflowContext(call *ast.CallExpr) bool {
+	for _, arg := range call.Args {
+		if ident, ok := arg.(*ast.Ident); ok && d.ctxParam != "" && ident.Name == d.ctxParam {
+			return true
+		}
+	}
+	lit, ok := call.Fun.(*ast.FuncLit)
+	if !ok {
+		return false
+	}
+	shadowed := false
+	for _, field := range lit.Type.Params.List {
+		if pkg, name, ok := resolveSelector(d.ctx.ImportMap, field.Type); ok && isWorkflowPackage(pkg) && name == "Context" {
+			return true
+		}
+		for _, name := range field.Names {
+			shadowed = shadowed || name.Name == d.ctxParam
+		}
+	}
+	if d.ctxParam == "" || shadowed {
+		return false
+	}
+	found := false
+	ast.Inspect(lit.Body, func(m ast.Node) bool {
+		if ident, ok := m.(*ast.Ident); ok && ident.Name == d.ctxParam {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// checkSharedBuffers adds an info for each bytes.Buffer or strings.Builder of
+// the enclosing function that the goroutine's closure writes to. Neither type
+// is safe for concurrent use, so this is a data race on top of the goroutine.
+func (d *GoroutineDetector) checkSharedBuffers(stmt *ast.GoStmt) {
+	lit, ok := stmt.Call.Fun.(*ast.FuncLit)
+	if !ok || len(d.buffers) == 0 {
+		return
+	}
+	reported := map[string]bool{}
+	ast.Inspect(lit.Body, func(m ast.Node) bool {
+		call, ok := m.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		name := d.bufferWritten(call)
+		if name == "" || reported[name] {
+			return true
+		}
+		reported[name] = true
+		pos := d.ctx.Fset.Position(call.Pos())
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "SharedBuffer",
+			Severity: "info",
+			Message:  "Native goroutine writes to " + name + " (" + d.buffers[name] + "), which the enclosing function also holds. " + d.buffers[name] + " isn't safe for concurrent use; this is a data race as well as a goroutine in workflow code.",
+			Func:     d.currFunc,
+		})
+		return true
+	})
+}
+
+// bufferWritten returns the tracked buffer a call writes to: b.WriteString(...)
+// and friends, or fmt.Fprint*(b|&b, ...).
+func (d *GoroutineDetector) bufferWritten(call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	if pkg, name, ok := resolveSelector(d.ctx.ImportMap, sel); ok && pkg == "fmt" && strings.HasPrefix(name, "Fprint") && len(call.Args) > 0 {
+		arg := call.Args[0]
+		if u, ok := arg.(*ast.UnaryExpr); ok && u.Op == token.AND {
+			arg = u.X
+		}
+		if ident, ok := arg.(*ast.Ident); ok && d.buffers[ident.Name] != "" {
+			return ident.Name
+		}
+		return ""
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	if ok && d.buffers[ident.Name] != "" && (strings.HasPrefix(sel.Sel.Name, "Write") || sel.Sel.Name == "ReadFrom" || sel.Sel.Name == "Reset" || sel.Sel.Name == "Truncate" || sel.Sel.Name == "Grow") {
+		return ident.Name
+	}
+	return ""
+}
+
+// bufferVars heuristically finds the parameters and local variables of fn that
+// hold a bytes.Buffer or strings.Builder (by value or pointer).
+func bufferVars(importMap map[string]string, fn *ast.FuncDecl) map[string]string {
+	vars := map[string]string{}
+	typeName := func(expr ast.Expr) string {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.UnaryExpr:
+			if e.Op == token.AND {
+				expr = e.X
+			}
+		case *ast.CompositeLit:
+			expr = e.Type
+		case *ast.CallExpr:
+			if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "new" && len(e.Args) == 1 {
+				expr = e.Args[0]
+			} else if pkg, name, ok := resolveSelector(importMap, e.Fun); ok && pkg == "bytes" && strings.HasPrefix(name, "NewBuffer") {
+				return "bytes.Buffer"
+			}
+		}
+		if lit, ok := expr.(*ast.CompositeLit); ok {
+			expr = lit.Type
+		}
+		pkg, name, ok := resolveSelector(importMap, expr)
+		switch {
+		case ok && pkg == "bytes" && name == "Buffer":
+			return "bytes.Buffer"
+		case ok && pkg == "strings" && name == "Builder":
+			return "strings.Builder"
+		}
+		return ""
+	}
+	if fn.Type.Params != nil {
+		for _, field := range fn.Type.Params.List {
+			if t := typeName(field.Type); t != "" {
+				for _, name := range field.Names {
+					vars[name.Name] = t
+				}
+			}
+		}
+	}
+	if fn.Body == nil {
+		return vars
+	}
+	ast.Inspect(fn.Body, func(m ast.Node) bool {
+		switch s := m.(type) {
+		case *ast.FuncLit:
+			return false // declarations inside closures aren't shared with the caller
+		case *ast.ValueSpec:
+			for i, name := range s.Names {
+				t := ""
+				if s.Type != nil {
+					t = typeName(s.Type)
+				} else if i < len(s.Values) {
+					t = typeName(s.Values[i])
+				}
+				if t != "" {
+					vars[name.Name] = t
+				}
+			}
+		case *ast.AssignStmt:
+			if s.Tok != token.DEFINE || len(s.Lhs) != len(s.Rhs) {
+				return true
+			}
+			for i, lhs := range s.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					if t := typeName(s.Rhs[i]); t != "" {
+						vars[ident.Name] = t
+					}
+				}
+			}
+		}
+		return true
+	})
+	return vars
+}
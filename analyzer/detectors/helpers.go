@@ -0,0 +1,74 @@
+package detectors
+
+import (
+	"go/ast"
+	"strings"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// resolveSelector returns the import path and selected name for pkg.Name expressions,
+// using the file's import map (falling back to the alias for stdlib packages).
+func resolveSelector(importMap map[string]string, expr ast.Expr) (string, string, bool) {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", "", false
+	}
+	importPath := importMap[ident.Name]
+	if importPath == "" {
+		importPath = ident.Name
+	}
+	return importPath, sel.Sel.Name, true
+}
+
+// isWorkflowPackage reports whether an import path is the Cadence workflow package
+// (or a module-local package named workflow, as used by the testdata modules).
+func isWorkflowPackage(importPath string) bool {
+	return importPath == "workflow" || strings.HasSuffix(importPath, "/workflow")
+}
+
+// isExecuteActivity reports whether call is workflow.ExecuteActivity or ExecuteLocalActivity.
+func isExecuteActivity(importMap map[string]string, call *ast.CallExpr) bool {
+	pkg, name, ok := resolveSelector(importMap, call.Fun)
+	return ok && isWorkflowPackage(pkg) && (name == "ExecuteActivity" || name == "ExecuteLocalActivity")
+}
+
+// inWorkflow checks reachability of pkgPath.funcName from any workflow.
+func inWorkflow(wr *registry.WorkflowRegistry, pkgPath, funcName string) bool {
+	return wr != nil && wr.IsWorkflowReachable(registry.NewFuncID(pkgPath, funcName))
+}
+
+// qualifiedType returns "importpath.Name" for a package-qualified type expression,
+// looking through a single pointer (e.g. *sync.Map -> "sync.Map").
+func qualifiedType(importMap map[string]string, expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	pkg, name, ok := resolveSelector(importMap, expr)
+	if !ok {
+		return ""
+	}
+	return pkg + "." + name
+}
+
+// valueType is a best-effort guess of the qualified type of a value expression
+// built from a composite literal (T{}, &T{}) or new(T).
+func valueType(importMap map[string]string, expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.UnaryExpr:
+		return valueType(importMap, e.X)
+	case *ast.CompositeLit:
+		if e.Type != nil {
+			return qualifiedType(importMap, e.Type)
+		}
+	case *ast.CallExpr:
+		if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "new" && len(e.Args) == 1 {
+			return qualifiedType(importMap, e.Args[0])
+		}
+	}
+	return ""
+}
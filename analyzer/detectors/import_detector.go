@@ -22,9 +22,10 @@ func (d *ImportDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d
 func (d *ImportDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
 func (d *ImportDetector) Issues() []Issue                                    { return d.issues }
 
-// Warn on disallowed imports only if the file contains at least one workflow
+// Warn on disallowed imports only if the file contains at least one workflow.
+// Without a registry there are no known workflows, so nothing is reported.
 func (d *ImportDetector) Visit(node ast.Node) ast.Visitor {
-	if len(d.wr.WorkflowFuncs) == 0 {
+	if d.wr == nil || len(d.wr.WorkflowFuncs) == 0 {
 		return d
 	}
 	switch n := node.(type) {
@@ -0,0 +1,146 @@
+package detectors
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// MapRangeDetector flags ranging over maps in workflow code. Go randomizes map
+// iteration order, so anything the loop does in order (scheduling activities,
+// appending results) can differ on replay. Only expressions the detector can
+// tell are maps from their declaration in the file are flagged.
+type MapRangeDetector struct {
+	ctx       FileContext
+	wr        *registry.WorkflowRegistry
+	currFunc  string
+	pkgPath   string
+	issues    []Issue
+	mapTypes  map[string]bool // named types declared in this file as maps
+	pkgVars   map[string]bool // package-level map variables
+	localVars map[string]bool // map variables of the current function
+}
+
+func NewMapRangeDetector() *MapRangeDetector {
+	return &MapRangeDetector{issues: []Issue{}}
+}
+
+func (d *MapRangeDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *MapRangeDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *MapRangeDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *MapRangeDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *MapRangeDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.File:
+		d.mapTypes = map[string]bool{}
+		d.pkgVars = map[string]bool{}
+		d.localVars = map[string]bool{}
+		for _, decl := range n.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok && d.isMapType(ts.Type) {
+					d.mapTypes[ts.Name.Name] = true
+				}
+			}
+		}
+		for _, decl := range n.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.VAR {
+				for _, spec := range gen.Specs {
+					d.trackSpec(spec.(*ast.ValueSpec), d.pkgVars)
+				}
+			}
+		}
+
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+		d.localVars = map[string]bool{}
+		if n.Type.Params != nil {
+			for _, field := range n.Type.Params.List {
+				if d.isMapType(field.Type) {
+					for _, name := range field.Names {
+						d.localVars[name.Name] = true
+					}
+				}
+			}
+		}
+
+	case *ast.ValueSpec:
+		d.trackSpec(n, d.localVars)
+
+	case *ast.AssignStmt:
+		if n.Tok == token.DEFINE && len(n.Lhs) == len(n.Rhs) {
+			for i, lhs := range n.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					d.localVars[ident.Name] = d.isMapValue(n.Rhs[i])
+				}
+			}
+		}
+
+	case *ast.RangeStmt:
+		if !d.rangesOverMap(n.X) || !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+			return d
+		}
+		pos := d.ctx.Fset.Position(n.For)
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "NonDeterminism",
+			Severity: "warning",
+			Message:  "Detected range over a map in workflow. Map iteration order is randomized, so replays can take a different path; collect the keys, sort them and iterate over the sorted slice.",
+			Func:     d.currFunc,
+		})
+	}
+	return d
+}
+
+func (d *MapRangeDetector) trackSpec(vs *ast.ValueSpec, vars map[string]bool) {
+	for i, name := range vs.Names {
+		if (vs.Type != nil && d.isMapType(vs.Type)) || (vs.Type == nil && i < len(vs.Values) && d.isMapValue(vs.Values[i])) {
+			vars[name.Name] = true
+		}
+	}
+}
+
+func (d *MapRangeDetector) rangesOverMap(x ast.Expr) bool {
+	if ident, ok := x.(*ast.Ident); ok {
+		if local, declared := d.localVars[ident.Name]; declared {
+			return local
+		}
+		return d.pkgVars[ident.Name]
+	}
+	return d.isMapValue(x)
+}
+
+// isMapType reports whether a type expression is a map or a map type named in this file.
+func (d *MapRangeDetector) isMapType(expr ast.Expr) bool {
+	switch t := expr.(type) {
+	case *ast.MapType:
+		return true
+	case *ast.Ident:
+		return d.mapTypes[t.Name]
+	case *ast.ParenExpr:
+		return d.isMapType(t.X)
+	}
+	return false
+}
+
+// isMapValue reports whether a value expression is a map literal or make(map...).
+func (d *MapRangeDetector) isMapValue(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		return e.Type != nil && d.isMapType(e.Type)
+	case *ast.CallExpr:
+		if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "make" && len(e.Args) > 0 {
+			return d.isMapType(e.Args[0])
+		}
+	case *ast.ParenExpr:
+		return d.isMapValue(e.X)
+	}
+	return false
+}
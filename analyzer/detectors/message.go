@@ -0,0 +1,30 @@
+package detectors
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MessageVars are the values substituted into message templates.
+type MessageVars struct {
+	Func    string // %FUNC%: the called function for call rules, else the function containing the issue
+	Pkg     string // %PKG%: the called function's import path for call rules, else the file's package path
+	File    string // %FILE%
+	Line    int    // %LINE%
+	Message string // %MESSAGE%: the built-in message
+}
+
+// RenderMessage substitutes the %FUNC%, %PKG%, %FILE%, %LINE% and %MESSAGE%
+// placeholders of tmpl.
+func RenderMessage(tmpl string, v MessageVars) string {
+	if !strings.Contains(tmpl, "%") {
+		return tmpl
+	}
+	return strings.NewReplacer(
+		"%FUNC%", v.Func,
+		"%PKG%", v.Pkg,
+		"%FILE%", v.File,
+		"%LINE%", strconv.Itoa(v.Line),
+		"%MESSAGE%", v.Message,
+	).Replace(tmpl)
+}
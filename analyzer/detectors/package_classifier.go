@@ -0,0 +1,108 @@
+package detectors
+
+import (
+	"strings"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/modutils"
+)
+
+// PackageClass is how FuncCallDetector treats calls into an imported package
+// that no function rule covers.
+type PackageClass int
+
+const (
+	// PackageUndecided defers to the next classifier (ultimately the default).
+	PackageUndecided PackageClass = iota
+	// PackageStdlib is the standard library or golang.org/x.
+	PackageStdlib
+	// PackageInternal belongs to the project being linted.
+	PackageInternal
+	// PackageSafe is a third-party package known to be workflow-safe.
+	PackageSafe
+	// PackageUnknownExternal is third-party code nobody vouched for; calls
+	// into it are reported as UnknownExternalCall.
+	PackageUnknownExternal
+)
+
+// PackageClassifier decides the class of an import path. Custom classifiers
+// return PackageUndecided for packages they have no opinion about.
+type PackageClassifier interface {
+	Classify(importPath string) PackageClass
+}
+
+// DefaultClassifier is the built-in classification: go.mod-aware project
+// detection plus a few well-known special cases.
+type DefaultClassifier struct {
+	ModuleInfo   *modutils.ModuleInfo
+	SafePackages []string        // safe_external_packages; prefixes match subpackages
+	RulePackages map[string]bool // packages covered by external_packages rules
+}
+
+func (c *DefaultClassifier) Classify(importPath string) PackageClass {
+	// Standard library packages (no dots, or golang.org/x/)
+	if !strings.Contains(importPath, ".") || strings.HasPrefix(importPath, "golang.org/x/") {
+		return PackageStdlib
+	}
+
+	// Cadence framework packages are expected and safe
+	if strings.HasPrefix(importPath, "go.uber.org/cadence") {
+		return PackageSafe
+	}
+
+	// Packages with their own rules are judged by those rules
+	if c.RulePackages[importPath] {
+		return PackageSafe
+	}
+
+	if c.isSafe(importPath) {
+		return PackageSafe
+	}
+
+	if c.isInternal(importPath) {
+		return PackageInternal
+	}
+
+	// If we get here, it's likely an external third-party package we don't know about
+	return PackageUnknownExternal
+}
+
+func (c *DefaultClassifier) isSafe(importPath string) bool {
+	for _, safePkg := range c.SafePackages {
+		if importPath == safePkg || strings.HasPrefix(importPath, safePkg+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// isInternal determines if a package is internal using hybrid approach
+func (c *DefaultClassifier) isInternal(importPath string) bool {
+	// Solution 1: Use go.mod information if available
+	if c.ModuleInfo != nil {
+		// Check if it's an internal package according to go.mod
+		if c.ModuleInfo.IsInternalPackage(importPath) {
+			return true
+		}
+
+		// Check if it's replaced by a local path (also considered internal)
+		if isReplaced, newPath := c.ModuleInfo.IsReplacedPackage(importPath); isReplaced {
+			// If replaced with local path, consider it internal
+			if !strings.Contains(newPath, "/") || strings.HasPrefix(newPath, "./") || strings.HasPrefix(newPath, "../") {
+				return true
+			}
+		}
+	}
+
+	// Solution 3: Enhanced heuristics as fallback
+	// Hardcoded project path as fallback when go.mod is not available
+	if strings.HasPrefix(importPath, "github.com/afony10/cadence-workflow-linter") {
+		return true
+	}
+
+	// Testdata packages are considered internal for testing purposes
+	if strings.HasPrefix(importPath, "testdata/") || strings.HasPrefix(importPath, "example.com/linttest/") {
+		return true
+	}
+
+	return false
+}
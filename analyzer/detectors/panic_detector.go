@@ -0,0 +1,90 @@
+package detectors
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// PanicDetector flags panic and recover in workflow code. A panicking workflow
+// fails its decision task, which Cadence retries until a fix is deployed;
+// recover can swallow the panics the Cadence client uses internally.
+// A function that recovers and re-panics (log-and-rethrow middleware) is
+// reported once, as a Recover info, instead of as a separate panic and recover.
+type PanicDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	issues   []Issue
+}
+
+func NewPanicDetector() *PanicDetector {
+	return &PanicDetector{issues: []Issue{}}
+}
+
+func (d *PanicDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *PanicDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *PanicDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *PanicDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *PanicDetector) Visit(node ast.Node) ast.Visitor {
+	if fn, ok := node.(*ast.FuncDecl); ok {
+		d.currFunc = registry.FuncDeclName(fn)
+		if fn.Body != nil && inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+			d.checkScope(fn.Body)
+		}
+	}
+	return d
+}
+
+// checkScope reports the panic and recover calls of one function body;
+// closures are checked as scopes of their own.
+func (d *PanicDetector) checkScope(body *ast.BlockStmt) {
+	var panics, recovers []token.Pos
+	ast.Inspect(body, func(m ast.Node) bool {
+		switch n := m.(type) {
+		case *ast.FuncLit:
+			d.checkScope(n.Body)
+			return false
+		case *ast.CallExpr:
+			if ident, ok := n.Fun.(*ast.Ident); ok {
+				switch ident.Name {
+				case "panic":
+					panics = append(panics, n.Pos())
+				case "recover":
+					recovers = append(recovers, n.Pos())
+				}
+			}
+		}
+		return true
+	})
+
+	if len(panics) > 0 && len(recovers) > 0 {
+		d.report(recovers[0], "Recover", "info",
+			"Recovered panic is re-panicked in workflow. The workflow still fails its decision task; logging before re-panicking is fine, but avoid recover-based control flow in workflows.")
+		return
+	}
+	for _, pos := range panics {
+		d.report(pos, "Panic", "warning",
+			"Detected panic in workflow. A panic fails the decision task, which is retried until the code is fixed; return an error to fail the workflow instead.")
+	}
+	for _, pos := range recovers {
+		d.report(pos, "Panic", "warning",
+			"Detected recover in workflow. It can swallow panics the Cadence client relies on, e.g. to unwind blocked coroutines and fail replays that diverge; handle recovery at the activity or worker level and return errors from workflows.")
+	}
+}
+
+func (d *PanicDetector) report(p token.Pos, rule, severity, message string) {
+	pos := d.ctx.Fset.Position(p)
+	d.issues = append(d.issues, Issue{
+		File:     d.ctx.File,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Rule:     rule,
+		Severity: severity,
+		Message:  message,
+		Func:     d.currFunc,
+	})
+}
@@ -0,0 +1,85 @@
+package detectors
+
+import (
+	"go/ast"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// wordSizeConsts are math constants whose value depends on the platform's int size.
+var wordSizeConsts = map[string]bool{"MaxInt": true, "MinInt": true, "MaxUint": true}
+
+// PlatformDependentDetector notes workflow branches on math.MaxInt and
+// friends. They differ between 32- and 64-bit workers, so a history recorded
+// on one can take a different branch when replayed on the other.
+type PlatformDependentDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	issues   []Issue
+}
+
+func NewPlatformDependentDetector() *PlatformDependentDetector {
+	return &PlatformDependentDetector{issues: []Issue{}}
+}
+
+func (d *PlatformDependentDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) {
+	d.wr = reg
+}
+func (d *PlatformDependentDetector) SetFileContext(ctx FileContext) { d.ctx = ctx }
+func (d *PlatformDependentDetector) SetPackagePath(pkgPath string)  { d.pkgPath = pkgPath }
+func (d *PlatformDependentDetector) Issues() []Issue                { return d.issues }
+
+func (d *PlatformDependentDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+
+	case *ast.IfStmt:
+		d.checkCondition(n.Cond)
+
+	case *ast.ForStmt:
+		d.checkCondition(n.Cond)
+
+	case *ast.SwitchStmt:
+		d.checkCondition(n.Tag)
+
+	case *ast.CaseClause:
+		for _, e := range n.List {
+			d.checkCondition(e)
+		}
+	}
+	return d
+}
+
+// checkCondition reports word-size constants used in a branch condition.
+func (d *PlatformDependentDetector) checkCondition(cond ast.Expr) {
+	if cond == nil || !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+		return
+	}
+	ast.Inspect(cond, func(m ast.Node) bool {
+		if _, ok := m.(*ast.FuncLit); ok {
+			return false
+		}
+		sel, ok := m.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkg, name, _ := resolveSelector(d.ctx.ImportMap, sel)
+		if pkg != "math" || !wordSizeConsts[name] {
+			return true
+		}
+		pos := d.ctx.Fset.Position(sel.Pos())
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "PlatformDependent",
+			Severity: "info",
+			Message:  "Detected math." + name + " in a workflow branch. Its value depends on the worker's word size, so 32- and 64-bit workers can take different paths on replay; compare against a sized constant such as math.MaxInt64.",
+			Func:     d.currFunc,
+		})
+		return false
+	})
+}
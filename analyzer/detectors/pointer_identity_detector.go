@@ -0,0 +1,128 @@
+package detectors
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// PointerIdentityDetector notes workflow code comparing two pointers with ==
+// or !=. Whether two pointers are equal depends on how values were allocated,
+// which a replay or a code change can alter. Without type information,
+// operands count as pointers when they are &x, new(T), or variables declared
+// as *T or initialized from one of those.
+type PointerIdentityDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	issues   []Issue
+	pointers map[string]bool // pointer-typed names in the current function
+}
+
+func NewPointerIdentityDetector() *PointerIdentityDetector {
+	return &PointerIdentityDetector{issues: []Issue{}}
+}
+
+func (d *PointerIdentityDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) {
+	d.wr = reg
+}
+func (d *PointerIdentityDetector) SetFileContext(ctx FileContext) { d.ctx = ctx }
+func (d *PointerIdentityDetector) SetPackagePath(pkgPath string)  { d.pkgPath = pkgPath }
+func (d *PointerIdentityDetector) Issues() []Issue                { return d.issues }
+
+func (d *PointerIdentityDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+		d.pointers = pointerVars(n)
+
+	case *ast.BinaryExpr:
+		if n.Op != token.EQL && n.Op != token.NEQ {
+			return d
+		}
+		if !d.isPointer(n.X) || !d.isPointer(n.Y) || !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+			return d
+		}
+		pos := d.ctx.Fset.Position(n.OpPos)
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "PointerIdentity",
+			Severity: "info",
+			Message:  "Detected pointer comparison (" + n.Op.String() + ") in workflow. Pointer identity depends on allocation, not on the values, so it can change between runs; compare the values or an ID field instead.",
+			Func:     d.currFunc,
+		})
+	}
+	return d
+}
+
+func (d *PointerIdentityDetector) isPointer(expr ast.Expr) bool {
+	if ident, ok := expr.(*ast.Ident); ok {
+		return d.pointers[ident.Name]
+	}
+	return isPointerValue(expr)
+}
+
+// isPointerValue reports whether expr is &x or new(T).
+func isPointerValue(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return isPointerValue(e.X)
+	case *ast.UnaryExpr:
+		return e.Op == token.AND
+	case *ast.CallExpr:
+		ident, ok := e.Fun.(*ast.Ident)
+		return ok && ident.Name == "new"
+	}
+	return false
+}
+
+// pointerVars returns the names in fn declared with a *T type or initialized
+// from &x or new(T). Like declaredNames, it ignores scoping.
+func pointerVars(fn *ast.FuncDecl) map[string]bool {
+	names := map[string]bool{}
+	addFields := func(fl *ast.FieldList) {
+		if fl == nil {
+			return
+		}
+		for _, field := range fl.List {
+			if _, ok := field.Type.(*ast.StarExpr); ok {
+				for _, name := range field.Names {
+					names[name.Name] = true
+				}
+			}
+		}
+	}
+	addFields(fn.Recv)
+	addFields(fn.Type.Params)
+	if fn.Body == nil {
+		return names
+	}
+	ast.Inspect(fn.Body, func(m ast.Node) bool {
+		switch s := m.(type) {
+		case *ast.AssignStmt:
+			if s.Tok != token.DEFINE || len(s.Lhs) != len(s.Rhs) {
+				return true
+			}
+			for i, lhs := range s.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && isPointerValue(s.Rhs[i]) {
+					names[ident.Name] = true
+				}
+			}
+		case *ast.ValueSpec:
+			_, star := s.Type.(*ast.StarExpr)
+			for i, name := range s.Names {
+				if star || (i < len(s.Values) && isPointerValue(s.Values[i])) {
+					names[name.Name] = true
+				}
+			}
+		case *ast.FuncLit:
+			addFields(s.Type.Params)
+		}
+		return true
+	})
+	return names
+}
@@ -0,0 +1,142 @@
+package detectors
+
+import (
+	"fmt"
+	"go/ast"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// PostCallMutationDetector flags variables mutated (append, index assignment)
+// after being passed to workflow.ExecuteActivity and before the returned
+// future's Get. The activity input is only serialized when the call is
+// scheduled, so such mutations race with it and differ on replay.
+type PostCallMutationDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	issues   []Issue
+}
+
+// pendingArg is a variable passed to an activity whose future hasn't been read yet.
+type pendingArg struct {
+	future string // variable holding the future; "" if discarded
+	line   int    // line of the ExecuteActivity call
+}
+
+func NewPostCallMutationDetector() *PostCallMutationDetector {
+	return &PostCallMutationDetector{issues: []Issue{}}
+}
+
+func (d *PostCallMutationDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *PostCallMutationDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *PostCallMutationDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *PostCallMutationDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *PostCallMutationDetector) Visit(node ast.Node) ast.Visitor {
+	if fn, ok := node.(*ast.FuncDecl); ok {
+		d.currFunc = registry.FuncDeclName(fn)
+		if fn.Body != nil && inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+			d.checkBody(fn.Body)
+		}
+	}
+	return d
+}
+
+// checkBody walks the body in source order, tracking activity arguments until
+// their future is read. The ordering is syntactic: branches and loops aren't modeled.
+func (d *PostCallMutationDetector) checkBody(body *ast.BlockStmt) {
+	pending := map[string]pendingArg{}
+	handled := map[*ast.CallExpr]bool{}
+
+	track := func(call *ast.CallExpr, future string) {
+		handled[call] = true
+		line := d.ctx.Fset.Position(call.Pos()).Line
+		for _, arg := range call.Args[min(2, len(call.Args)):] {
+			if ident, ok := arg.(*ast.Ident); ok {
+				pending[ident.Name] = pendingArg{future: future, line: line}
+			}
+		}
+	}
+
+	ast.Inspect(body, func(m ast.Node) bool {
+		switch s := m.(type) {
+		case *ast.AssignStmt:
+			// f := workflow.ExecuteActivity(ctx, A, items)
+			if len(s.Rhs) == 1 && len(s.Lhs) == 1 {
+				if call, ok := s.Rhs[0].(*ast.CallExpr); ok && isExecuteActivity(d.ctx.ImportMap, call) {
+					if ident, ok := s.Lhs[0].(*ast.Ident); ok && ident.Name != "_" {
+						track(call, ident.Name)
+					}
+				}
+			}
+			for i, lhs := range s.Lhs {
+				var rhs ast.Expr
+				if len(s.Lhs) == len(s.Rhs) {
+					rhs = s.Rhs[i]
+				}
+				d.checkMutation(pending, lhs, rhs)
+			}
+
+		case *ast.IncDecStmt:
+			d.checkMutation(pending, s.X, nil)
+
+		case *ast.CallExpr:
+			sel, ok := s.Fun.(*ast.SelectorExpr)
+			if ok && sel.Sel.Name == "Get" {
+				switch x := sel.X.(type) {
+				case *ast.CallExpr:
+					// ExecuteActivity(...).Get(...) resolves immediately
+					if isExecuteActivity(d.ctx.ImportMap, x) {
+						handled[x] = true
+					}
+				case *ast.Ident:
+					for name, p := range pending {
+						if p.future == x.Name {
+							delete(pending, name)
+						}
+					}
+				}
+			}
+			if isExecuteActivity(d.ctx.ImportMap, s) && !handled[s] {
+				track(s, "")
+			}
+		}
+		return true
+	})
+}
+
+// checkMutation reports `x[i] = ...`, `x[i]++` and `x = append(x, ...)` on pending variables.
+func (d *PostCallMutationDetector) checkMutation(pending map[string]pendingArg, lhs, rhs ast.Expr) {
+	var name string
+	switch e := lhs.(type) {
+	case *ast.IndexExpr:
+		if ident, ok := e.X.(*ast.Ident); ok {
+			name = ident.Name
+		}
+	case *ast.Ident:
+		if call, ok := rhs.(*ast.CallExpr); ok {
+			if fn, ok := call.Fun.(*ast.Ident); ok && fn.Name == "append" && len(call.Args) > 0 {
+				if first, ok := call.Args[0].(*ast.Ident); ok && first.Name == e.Name {
+					name = e.Name
+				}
+			}
+		}
+	}
+	p, ok := pending[name]
+	if name == "" || !ok {
+		return
+	}
+	pos := d.ctx.Fset.Position(lhs.Pos())
+	d.issues = append(d.issues, Issue{
+		File:     d.ctx.File,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Rule:     "PostCallMutation",
+		Severity: "info",
+		Message: fmt.Sprintf("%s is mutated after being passed to ExecuteActivity on line %d and before its future's Get. "+
+			"Copy the value before the call or mutate it only after the result is in.", name, p.line),
+		Func: d.currFunc,
+	})
+}
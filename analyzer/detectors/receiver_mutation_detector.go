@@ -0,0 +1,78 @@
+package detectors
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// ReceiverMutationDetector flags assignments to receiver fields in workflow
+// methods. The receiver is usually registered once and shared by every
+// execution on the worker, and its fields aren't part of workflow history, so
+// their values differ between the original run and a replay.
+type ReceiverMutationDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	recv     string // receiver name of the current method, "" for functions
+	issues   []Issue
+}
+
+func NewReceiverMutationDetector() *ReceiverMutationDetector {
+	return &ReceiverMutationDetector{issues: []Issue{}}
+}
+
+func (d *ReceiverMutationDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *ReceiverMutationDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *ReceiverMutationDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *ReceiverMutationDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *ReceiverMutationDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+		d.recv = ""
+		if n.Recv != nil && len(n.Recv.List) == 1 && len(n.Recv.List[0].Names) == 1 {
+			if name := n.Recv.List[0].Names[0].Name; name != "_" {
+				d.recv = name
+			}
+		}
+
+	case *ast.AssignStmt:
+		if n.Tok != token.DEFINE {
+			for _, lhs := range n.Lhs {
+				d.checkTarget(lhs)
+			}
+		}
+
+	case *ast.IncDecStmt:
+		d.checkTarget(n.X)
+	}
+	return d
+}
+
+// checkTarget flags lhs when it is a field (or element of a field) of the receiver.
+func (d *ReceiverMutationDetector) checkTarget(lhs ast.Expr) {
+	if d.recv == "" {
+		return
+	}
+	if _, ok := lhs.(*ast.Ident); ok {
+		return // reassigning the receiver variable itself changes nothing shared
+	}
+	ident := rootIdent(lhs)
+	if ident == nil || ident.Name != d.recv || !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+		return
+	}
+	pos := d.ctx.Fset.Position(lhs.Pos())
+	d.issues = append(d.issues, Issue{
+		File:     d.ctx.File,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Rule:     "ReceiverMutation",
+		Severity: "warning",
+		Message:  "Detected assignment to a field of receiver " + d.recv + " in workflow code. The receiver is shared by all executions on the worker and isn't persisted in workflow history; keep execution state in local variables.",
+		Func:     d.currFunc,
+	})
+}
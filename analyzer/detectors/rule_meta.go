@@ -0,0 +1,80 @@
+package detectors
+
+import "sort"
+
+// Rule categories group rules by the kind of problem they catch.
+const (
+	CategoryDeterminism = "Determinism"
+	CategoryConcurrency = "Concurrency"
+	CategoryIO          = "IO"
+	CategoryReliability = "Reliability"
+)
+
+// RuleMeta describes a rule independently of the detector that reports it.
+type RuleMeta struct {
+	Rule     string
+	Category string
+}
+
+// ruleMeta is the single place categories are assigned. Rules missing here
+// (e.g. custom rules from a rules file) have no category.
+var ruleMeta = map[string]RuleMeta{
+	"TimeUsage":           {Rule: "TimeUsage", Category: CategoryDeterminism},
+	"Randomness":          {Rule: "Randomness", Category: CategoryDeterminism},
+	"ImportRandom":        {Rule: "ImportRandom", Category: CategoryDeterminism},
+	"UUIDGeneration":      {Rule: "UUIDGeneration", Category: CategoryDeterminism},
+	"RuntimeUsage":        {Rule: "RuntimeUsage", Category: CategoryDeterminism},
+	"BuildDependent":      {Rule: "BuildDependent", Category: CategoryDeterminism},
+	"GobEncoding":         {Rule: "GobEncoding", Category: CategoryDeterminism},
+	"ClockAbstraction":    {Rule: "ClockAbstraction", Category: CategoryDeterminism},
+	"EnvironmentAccess":   {Rule: "EnvironmentAccess", Category: CategoryDeterminism},
+	"NonDeterminism":      {Rule: "NonDeterminism", Category: CategoryDeterminism},
+	"ReceiverMutation":    {Rule: "ReceiverMutation", Category: CategoryDeterminism},
+	"PlatformDependent":   {Rule: "PlatformDependent", Category: CategoryDeterminism},
+	"PointerIdentity":     {Rule: "PointerIdentity", Category: CategoryDeterminism},
+	"Concurrency":         {Rule: "Concurrency", Category: CategoryConcurrency},
+	"PostCallMutation":    {Rule: "PostCallMutation", Category: CategoryConcurrency},
+	"SharedBuffer":        {Rule: "SharedBuffer", Category: CategoryConcurrency},
+	"UnawaitedWorkflowGo": {Rule: "UnawaitedWorkflowGo", Category: CategoryConcurrency},
+	"LazyInit":            {Rule: "LazyInit", Category: CategoryConcurrency},
+	"IOCalls":             {Rule: "IOCalls", Category: CategoryIO},
+	"Network":             {Rule: "Network", Category: CategoryIO},
+	"NetworkIO":           {Rule: "NetworkIO", Category: CategoryIO},
+	"HTTPClient":          {Rule: "HTTPClient", Category: CategoryIO},
+	"RedisOperations":     {Rule: "RedisOperations", Category: CategoryIO},
+	"TimeSerialization":   {Rule: "TimeSerialization", Category: CategoryReliability},
+	"Serialization":       {Rule: "Serialization", Category: CategoryReliability},
+	"DeferInLoop":         {Rule: "DeferInLoop", Category: CategoryReliability},
+	"BusyWait":            {Rule: "BusyWait", Category: CategoryReliability},
+	"ContextMisuse":       {Rule: "ContextMisuse", Category: CategoryReliability},
+	"DirectActivityCall":  {Rule: "DirectActivityCall", Category: CategoryReliability},
+	"UnknownActivityName": {Rule: "UnknownActivityName", Category: CategoryReliability},
+	"MissingTimeout":      {Rule: "MissingTimeout", Category: CategoryReliability},
+	"Panic":               {Rule: "Panic", Category: CategoryReliability},
+	"TimerNotStopped":     {Rule: "TimerNotStopped", Category: CategoryReliability},
+	"Recover":             {Rule: "Recover", Category: CategoryReliability},
+
+	"UnknownExternalCall":  {Rule: "UnknownExternalCall", Category: CategoryReliability},
+	"UnregisteredWorkflow": {Rule: "UnregisteredWorkflow", Category: CategoryReliability},
+}
+
+// Meta returns the metadata of a rule; ok is false for unknown rules.
+func Meta(rule string) (RuleMeta, bool) {
+	m, ok := ruleMeta[rule]
+	return m, ok
+}
+
+// CategoryOf returns the category of a rule, or "" if it has none.
+func CategoryOf(rule string) string {
+	return ruleMeta[rule].Category
+}
+
+// BuiltinRules returns the names of all rules with metadata, sorted.
+func BuiltinRules() []string {
+	names := make([]string, 0, len(ruleMeta))
+	for name := range ruleMeta {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
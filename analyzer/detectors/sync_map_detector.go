@@ -0,0 +1,107 @@
+package detectors
+
+import (
+	"go/ast"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// SyncMapDetector flags (*sync.Map).Range in workflows: it is shared concurrent
+// state and its iteration order is unspecified, so replays can diverge.
+type SyncMapDetector struct {
+	ctx       FileContext
+	wr        *registry.WorkflowRegistry
+	currFunc  string
+	pkgPath   string
+	issues    []Issue
+	pkgVars   map[string]bool // package-level sync.Map variables
+	localVars map[string]bool // sync.Map variables of the current function
+}
+
+func NewSyncMapDetector() *SyncMapDetector {
+	return &SyncMapDetector{issues: []Issue{}}
+}
+
+func (d *SyncMapDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *SyncMapDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *SyncMapDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *SyncMapDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *SyncMapDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.File:
+		d.pkgVars = map[string]bool{}
+		d.localVars = map[string]bool{}
+		for _, decl := range n.Decls {
+			if gen, ok := decl.(*ast.GenDecl); ok {
+				for _, spec := range gen.Specs {
+					if vs, ok := spec.(*ast.ValueSpec); ok {
+						d.trackSpec(vs, d.pkgVars)
+					}
+				}
+			}
+		}
+
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+		d.localVars = map[string]bool{}
+		if n.Type.Params != nil {
+			for _, field := range n.Type.Params.List {
+				if d.isSyncMap(qualifiedType(d.ctx.ImportMap, field.Type)) {
+					for _, name := range field.Names {
+						d.localVars[name.Name] = true
+					}
+				}
+			}
+		}
+
+	case *ast.ValueSpec:
+		d.trackSpec(n, d.localVars)
+
+	case *ast.AssignStmt:
+		if len(n.Lhs) == len(n.Rhs) {
+			for i, lhs := range n.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && d.isSyncMap(valueType(d.ctx.ImportMap, n.Rhs[i])) {
+					d.localVars[ident.Name] = true
+				}
+			}
+		}
+
+	case *ast.CallExpr:
+		sel, ok := n.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Range" {
+			return d
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok || !(d.localVars[ident.Name] || d.pkgVars[ident.Name]) {
+			return d
+		}
+		if !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+			return d
+		}
+		pos := d.ctx.Fset.Position(sel.Sel.Pos())
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "Concurrency",
+			Severity: "error",
+			Message:  "Detected sync.Map.Range in workflow. sync.Map is shared concurrent state and Range visits keys in unspecified order, so replays can diverge; keep state in workflow-local variables and iterate over sorted keys.",
+			Func:     d.currFunc,
+		})
+	}
+	return d
+}
+
+func (d *SyncMapDetector) trackSpec(vs *ast.ValueSpec, vars map[string]bool) {
+	for i, name := range vs.Names {
+		if (vs.Type != nil && d.isSyncMap(qualifiedType(d.ctx.ImportMap, vs.Type))) ||
+			(i < len(vs.Values) && d.isSyncMap(valueType(d.ctx.ImportMap, vs.Values[i]))) {
+			vars[name.Name] = true
+		}
+	}
+}
+
+func (d *SyncMapDetector) isSyncMap(typeName string) bool {
+	return typeName == "sync.Map"
+}
@@ -0,0 +1,243 @@
+package detectors
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// syncPrimitives are the sync types that block the goroutine they run on.
+// sync.Map is covered by SyncMapDetector.
+var syncPrimitives = map[string]bool{
+	"sync.Mutex":     true,
+	"sync.RWMutex":   true,
+	"sync.WaitGroup": true,
+	"sync.Once":      true,
+	"sync.Cond":      true,
+}
+
+// SyncPrimitiveDetector flags sync primitives and sync/atomic in workflow code.
+// Workflow coroutines are scheduled cooperatively by the Cadence client, so
+// blocking on a native lock or wait group can deadlock the workflow, and
+// atomics imply state shared with native goroutines. sync.Cond is an error:
+// its Wait only returns once another goroutine signals through shared memory.
+// Do on a package-level sync.Once is reported as LazyInit instead.
+type SyncPrimitiveDetector struct {
+	ctx       FileContext
+	wr        *registry.WorkflowRegistry
+	currFunc  string
+	funcEnd   token.Pos // end of the current function; later specs are package-level
+	pkgPath   string
+	issues    []Issue
+	pkgVars   map[string]string // package-level sync primitive variables -> type
+	localVars map[string]string // sync primitive variables of the current function -> type
+}
+
+func NewSyncPrimitiveDetector() *SyncPrimitiveDetector {
+	return &SyncPrimitiveDetector{issues: []Issue{}}
+}
+
+func (d *SyncPrimitiveDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *SyncPrimitiveDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *SyncPrimitiveDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *SyncPrimitiveDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *SyncPrimitiveDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.File:
+		d.pkgVars = map[string]string{}
+		d.localVars = map[string]string{}
+		if d.wr != nil {
+			// every file of the package, so a var declared next door is known
+			for name, decl := range d.wr.VarDecls[d.pkgPath] {
+				if t := d.declType(decl.ImportMap, decl.Type, decl.Value); t != "" {
+					d.pkgVars[name] = t
+				}
+			}
+		}
+		return d
+
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+		d.funcEnd = n.End()
+		d.localVars = map[string]string{}
+		if n.Type.Params != nil {
+			for _, field := range n.Type.Params.List {
+				if t := qualifiedType(d.ctx.ImportMap, field.Type); d.isPrimitive(t) {
+					for _, name := range field.Names {
+						d.localVars[name.Name] = t
+					}
+				}
+			}
+		}
+
+	case *ast.ValueSpec:
+		if n.Pos() > d.funcEnd {
+			return d // package-level, looked up in the registry on *ast.File
+		}
+		for i, name := range n.Names {
+			if t := d.specType(n, i); t != "" {
+				d.localVars[name.Name] = t
+				if i >= len(n.Values) || !d.isNewCond(n.Values[i]) {
+					d.report(name, t, "Detected "+t+" variable "+name.Name+" in workflow.")
+				}
+			}
+		}
+
+	case *ast.AssignStmt:
+		if len(n.Lhs) != len(n.Rhs) {
+			return d
+		}
+		for i, lhs := range n.Lhs {
+			ident, ok := lhs.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if t := d.valueType(n.Rhs[i]); d.isPrimitive(t) {
+				d.localVars[ident.Name] = t
+				if !d.isNewCond(n.Rhs[i]) {
+					d.report(ident, t, "Detected "+t+" variable "+ident.Name+" in workflow.")
+				}
+			}
+		}
+
+	case *ast.CallExpr:
+		if pkg, name, ok := resolveSelector(d.ctx.ImportMap, n.Fun); ok && pkg == "sync/atomic" {
+			d.report(n, "sync/atomic."+name, "Detected atomic."+name+"() in workflow.")
+			return d
+		}
+		if d.isNewCond(n) {
+			d.report(n, "sync.Cond", "Detected sync.NewCond() in workflow.")
+			return d
+		}
+		sel, ok := n.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return d
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return d
+		}
+		t, local := d.localVars[ident.Name]
+		if !local {
+			t = d.pkgVars[ident.Name]
+		}
+		if !local && t == "sync.Once" && sel.Sel.Name == "Do" {
+			d.reportLazyInit(sel.Sel, ident.Name)
+			return d
+		}
+		if t != "" {
+			d.report(sel.Sel, t, "Detected "+t+"."+sel.Sel.Name+"() on "+ident.Name+" in workflow.")
+		}
+	}
+	return d
+}
+
+// report flags a use of a primitive of type typeName.
+func (d *SyncPrimitiveDetector) report(at ast.Node, typeName, what string) {
+	if !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+		return
+	}
+	severity := "warning"
+	if typeName == "sync.Cond" {
+		severity = "error"
+	}
+	pos := d.ctx.Fset.Position(at.Pos())
+	d.issues = append(d.issues, Issue{
+		File:     d.ctx.File,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Rule:     "Concurrency",
+		Severity: severity,
+		Message:  what + " Native locks, condition variables, wait groups and atomics bypass the workflow's cooperative scheduler; coordinate with workflow.Channel, workflow.NewSelector or workflow.Await, or move the work into an activity.",
+		Func:     d.currFunc,
+	})
+}
+
+// reportLazyInit flags Do on a package-level sync.Once. The first workflow to
+// get there runs the initializer and every other one on the worker skips it,
+// so behavior depends on which execution happened to come first.
+func (d *SyncPrimitiveDetector) reportLazyInit(at ast.Node, name string) {
+	if !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+		return
+	}
+	pos := d.ctx.Fset.Position(at.Pos())
+	d.issues = append(d.issues, Issue{
+		File:     d.ctx.File,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Rule:     "LazyInit",
+		Severity: "warning",
+		Message:  "Detected sync.Once.Do() on package-level " + name + " in workflow. Lazy initialization is shared by every workflow on the worker, so only the first execution runs it and replays can take a different path; initialize at worker startup or move the work into an activity.",
+		Func:     d.currFunc,
+	})
+}
+
+// specType returns the sync primitive type of the i-th name of vs, or "".
+func (d *SyncPrimitiveDetector) specType(vs *ast.ValueSpec, i int) string {
+	var value ast.Expr
+	if i < len(vs.Values) {
+		value = vs.Values[i]
+	}
+	return d.declType(d.ctx.ImportMap, vs.Type, value)
+}
+
+// declType returns the sync primitive type of a variable declared with typ or
+// initialized with value, resolved with importMap, or "".
+func (d *SyncPrimitiveDetector) declType(importMap map[string]string, typ, value ast.Expr) string {
+	t := ""
+	if typ != nil {
+		t = qualifiedType(importMap, typ)
+	} else if value != nil {
+		t = syncValueType(importMap, value)
+	}
+	if d.isPrimitive(t) {
+		return t
+	}
+	return ""
+}
+
+// isPackageOnce reports whether name is a package-level sync.Once of pkgPath,
+// whose Do the detector reports as LazyInit.
+func isPackageOnce(wr *registry.WorkflowRegistry, pkgPath, name string) bool {
+	decl, ok := wr.VarDecls[pkgPath][name]
+	if !ok {
+		return false
+	}
+	if decl.Type != nil {
+		return qualifiedType(decl.ImportMap, decl.Type) == "sync.Once"
+	}
+	return decl.Value != nil && syncValueType(decl.ImportMap, decl.Value) == "sync.Once"
+}
+
+// valueType is syncValueType with the current file's imports.
+func (d *SyncPrimitiveDetector) valueType(expr ast.Expr) string {
+	return syncValueType(d.ctx.ImportMap, expr)
+}
+
+func (d *SyncPrimitiveDetector) isNewCond(expr ast.Expr) bool {
+	return isNewCond(d.ctx.ImportMap, expr)
+}
+
+// syncValueType is valueType extended with sync.NewCond(...), which yields a *sync.Cond.
+func syncValueType(importMap map[string]string, expr ast.Expr) string {
+	if isNewCond(importMap, expr) {
+		return "sync.Cond"
+	}
+	return valueType(importMap, expr)
+}
+
+func isNewCond(importMap map[string]string, expr ast.Expr) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	pkg, name, ok := resolveSelector(importMap, call.Fun)
+	return ok && pkg == "sync" && name == "NewCond"
+}
+
+func (d *SyncPrimitiveDetector) isPrimitive(typeName string) bool {
+	return syncPrimitives[typeName] || strings.HasPrefix(typeName, "sync/atomic.")
+}
@@ -0,0 +1,108 @@
+package detectors
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// nativeTimerFuncs are time constructors returning a timer or ticker that must be stopped.
+var nativeTimerFuncs = map[string]bool{
+	"NewTimer":  true,
+	"NewTicker": true,
+	"AfterFunc": true,
+}
+
+// TimerStopDetector notes native timers and tickers in workflow code that are
+// assigned to a variable but never stopped in the same function. It is a
+// secondary hint to the native timer rules: besides breaking replay, an
+// unstopped ticker keeps firing and leaks for the lifetime of the worker.
+type TimerStopDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	issues   []Issue
+}
+
+func NewTimerStopDetector() *TimerStopDetector {
+	return &TimerStopDetector{issues: []Issue{}}
+}
+
+func (d *TimerStopDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *TimerStopDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *TimerStopDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *TimerStopDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *TimerStopDetector) Visit(node ast.Node) ast.Visitor {
+	if fn, ok := node.(*ast.FuncDecl); ok {
+		d.currFunc = registry.FuncDeclName(fn)
+		if fn.Body != nil && inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+			d.checkBody(fn.Body)
+		}
+	}
+	return d
+}
+
+func (d *TimerStopDetector) checkBody(body *ast.BlockStmt) {
+	timers := map[string]token.Pos{} // variable -> constructor call position
+	var order []string
+	stopped := map[string]bool{}
+
+	record := func(name *ast.Ident, value ast.Expr) {
+		call, ok := value.(*ast.CallExpr)
+		if !ok || name.Name == "_" {
+			return
+		}
+		if pkg, fn, ok := resolveSelector(d.ctx.ImportMap, call.Fun); ok && pkg == "time" && nativeTimerFuncs[fn] {
+			if _, seen := timers[name.Name]; !seen {
+				order = append(order, name.Name)
+			}
+			timers[name.Name] = call.Pos()
+		}
+	}
+
+	ast.Inspect(body, func(m ast.Node) bool {
+		switch n := m.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == len(n.Rhs) {
+				for i, lhs := range n.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						record(ident, n.Rhs[i])
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if i < len(n.Values) {
+					record(name, n.Values[i])
+				}
+			}
+		case *ast.CallExpr:
+			// t.Stop(), including `defer t.Stop()`
+			if sel, ok := n.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Stop" {
+				if ident, ok := sel.X.(*ast.Ident); ok {
+					stopped[ident.Name] = true
+				}
+			}
+		}
+		return true
+	})
+
+	for _, name := range order {
+		if stopped[name] {
+			continue
+		}
+		pos := d.ctx.Fset.Position(timers[name])
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "TimerNotStopped",
+			Severity: "info",
+			Message:  "Native timer " + name + " is never stopped. Besides breaking replay, it leaks until it fires (tickers never stop); use workflow.NewTimer(ctx, d), or at least defer " + name + ".Stop().",
+			Func:     d.currFunc,
+		})
+	}
+}
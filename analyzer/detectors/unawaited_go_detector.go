@@ -0,0 +1,95 @@
+package detectors
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// awaitMethods are calls that block a workflow until other coroutines make
+// progress: channel receives, selectors, workflow.Await and WaitGroup.Wait.
+var awaitMethods = map[string]bool{
+	"Receive":            true,
+	"ReceiveWithTimeout": true,
+	"Select":             true,
+	"Await":              true,
+	"AwaitWithTimeout":   true,
+	"Wait":               true,
+}
+
+// UnawaitedGoDetector notes workflow.Go calls that nothing after them waits
+// on. When the workflow function returns, coroutines still running are
+// abandoned, so their work may silently be lost. As a heuristic, any channel
+// Receive, Selector.Select, workflow.Await or WaitGroup.Wait after the
+// workflow.Go call, outside the coroutine itself, counts as waiting.
+type UnawaitedGoDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	pkgPath  string
+	issues   []Issue
+}
+
+func NewUnawaitedGoDetector() *UnawaitedGoDetector {
+	return &UnawaitedGoDetector{issues: []Issue{}}
+}
+
+func (d *UnawaitedGoDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) { d.wr = reg }
+func (d *UnawaitedGoDetector) SetFileContext(ctx FileContext)                     { d.ctx = ctx }
+func (d *UnawaitedGoDetector) SetPackagePath(pkgPath string)                      { d.pkgPath = pkgPath }
+func (d *UnawaitedGoDetector) Issues() []Issue                                    { return d.issues }
+
+func (d *UnawaitedGoDetector) Visit(node ast.Node) ast.Visitor {
+	fn, ok := node.(*ast.FuncDecl)
+	if !ok {
+		return d
+	}
+	d.currFunc = registry.FuncDeclName(fn)
+	if fn.Body == nil || !inWorkflow(d.wr, d.pkgPath, d.currFunc) {
+		return d
+	}
+
+	var launches []*ast.CallExpr
+	var waits []token.Pos
+	ast.Inspect(fn.Body, func(m ast.Node) bool {
+		call, ok := m.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if pkg, name, ok := resolveSelector(d.ctx.ImportMap, call.Fun); ok && isWorkflowPackage(pkg) && (name == "Go" || name == "GoNamed") {
+			launches = append(launches, call)
+			return false // waits inside the coroutine don't await it
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && awaitMethods[sel.Sel.Name] {
+			waits = append(waits, call.Pos())
+		}
+		return true
+	})
+
+	for _, launch := range launches {
+		if awaitedAfter(launch.End(), waits) {
+			continue
+		}
+		pos := d.ctx.Fset.Position(launch.Pos())
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "UnawaitedWorkflowGo",
+			Severity: "info",
+			Message:  "Detected workflow.Go whose completion is never awaited. Coroutines still running when the workflow returns are abandoned; signal completion through a workflow.Channel, Selector, WaitGroup or workflow.Await before returning.",
+			Func:     d.currFunc,
+		})
+	}
+	return d
+}
+
+func awaitedAfter(after token.Pos, waits []token.Pos) bool {
+	for _, w := range waits {
+		if w > after {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package detectors
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// UnknownActivityNameDetector flags workflow.ExecuteActivity calls that name
+// the activity with a string literal no scanned registration uses. Such calls
+// fail at run time with an unregistered-activity error.
+type UnknownActivityNameDetector struct {
+	ctx      FileContext
+	wr       *registry.WorkflowRegistry
+	currFunc string
+	issues   []Issue
+}
+
+func NewUnknownActivityNameDetector() *UnknownActivityNameDetector {
+	return &UnknownActivityNameDetector{issues: []Issue{}}
+}
+
+func (d *UnknownActivityNameDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) {
+	d.wr = reg
+}
+func (d *UnknownActivityNameDetector) SetFileContext(ctx FileContext) { d.ctx = ctx }
+func (d *UnknownActivityNameDetector) Issues() []Issue                { return d.issues }
+
+func (d *UnknownActivityNameDetector) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case *ast.FuncDecl:
+		d.currFunc = registry.FuncDeclName(n)
+
+	case *ast.CallExpr:
+		pkg, name, ok := resolveSelector(d.ctx.ImportMap, n.Fun)
+		if !ok || !isWorkflowPackage(pkg) || name != "ExecuteActivity" || len(n.Args) < 2 || d.wr == nil {
+			return d
+		}
+		// Activities passed as functions or named by a variable aren't checked.
+		lit, ok := n.Args[1].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return d
+		}
+		activity, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return d
+		}
+		if registered, ok := d.wr.ActivityNameRegistered(activity); !ok || registered {
+			return d
+		}
+		pos := d.ctx.Fset.Position(lit.Pos())
+		d.issues = append(d.issues, Issue{
+			File:     d.ctx.File,
+			Line:     pos.Line,
+			Column:   pos.Column,
+			Rule:     "UnknownActivityName",
+			Severity: "warning",
+			Message:  "workflow.ExecuteActivity names activity " + lit.Value + ", but no scanned activity registration uses that name. The call fails at run time unless the name matches a registration exactly; check the spelling or pass the activity function instead.",
+			Func:     d.currFunc,
+		})
+	}
+	return d
+}
@@ -0,0 +1,54 @@
+package detectors
+
+import (
+	"go/ast"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+// UnregisteredWorkflowDetector notes workflow-signatured functions that are
+// neither registered nor called anywhere in the scanned files, which usually
+// means a forgotten workflow.Register. It runs in the detector pass, after the
+// registry has seen every file, and is opt-in because registration may live
+// outside the scanned targets.
+type UnregisteredWorkflowDetector struct {
+	ctx     FileContext
+	wr      *registry.WorkflowRegistry
+	pkgPath string
+	issues  []Issue
+}
+
+func NewUnregisteredWorkflowDetector() *UnregisteredWorkflowDetector {
+	return &UnregisteredWorkflowDetector{issues: []Issue{}}
+}
+
+func (d *UnregisteredWorkflowDetector) SetWorkflowRegistry(reg *registry.WorkflowRegistry) {
+	d.wr = reg
+}
+func (d *UnregisteredWorkflowDetector) SetFileContext(ctx FileContext) { d.ctx = ctx }
+func (d *UnregisteredWorkflowDetector) SetPackagePath(pkgPath string)  { d.pkgPath = pkgPath }
+func (d *UnregisteredWorkflowDetector) Issues() []Issue                { return d.issues }
+
+func (d *UnregisteredWorkflowDetector) Visit(node ast.Node) ast.Visitor {
+	fn, ok := node.(*ast.FuncDecl)
+	if !ok || fn.Recv != nil || d.wr == nil {
+		// Methods are registered through values (w.RegisterWorkflow(s.Run)),
+		// which can't be resolved syntactically.
+		return d
+	}
+	id := registry.NewFuncID(d.pkgPath, registry.FuncDeclName(fn))
+	if !d.wr.WorkflowFuncs[id] || d.wr.Registered[id] || d.wr.HasCallers(id) {
+		return d
+	}
+	pos := d.ctx.Fset.Position(fn.Name.Pos())
+	d.issues = append(d.issues, Issue{
+		File:     d.ctx.File,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Rule:     "UnregisteredWorkflow",
+		Severity: "info",
+		Message:  "Workflow " + fn.Name.Name + " is never registered or called in the scanned files. Register it with workflow.Register (or worker.RegisterWorkflow), or it can't be started.",
+		Func:     id.LocalName(),
+	})
+	return d
+}
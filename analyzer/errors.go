@@ -0,0 +1,48 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"go/scanner"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+// ErrTargetNotFound is returned (wrapped) when the file or directory to scan doesn't exist.
+var ErrTargetNotFound = errors.New("scan target not found")
+
+// ParseError reports a Go source file that failed to parse.
+type ParseError struct {
+	File string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse %s: %v", e.File, e.Err)
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// parseErrorIssues turns files that failed to parse into ParseError issues,
+// placed at the first syntax error when its position is known.
+func parseErrorIssues(errs []*ParseError) []detectors.Issue {
+	issues := make([]detectors.Issue, 0, len(errs))
+	for _, pe := range errs {
+		is := detectors.Issue{
+			File:     pe.File,
+			Rule:     "ParseError",
+			Severity: "error",
+			Message:  "File could not be parsed and was not analyzed: " + pe.Err.Error(),
+		}
+		var list scanner.ErrorList
+		if errors.As(pe.Err, &list) && len(list) > 0 {
+			is.Line, is.Column = list[0].Pos.Line, list[0].Pos.Column
+			is.Message = "File could not be parsed and was not analyzed: " + list[0].Msg
+			if len(list) > 1 {
+				is.Message += fmt.Sprintf(" (and %d more syntax errors)", len(list)-1)
+			}
+		}
+		issues = append(issues, is)
+	}
+	return issues
+}
@@ -0,0 +1,78 @@
+package analyzer
+
+import (
+	"errors"
+	"go/ast"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+	"github.com/afony10/cadence-workflow-linter/analyzer/modutils"
+	"github.com/afony10/cadence-workflow-linter/config"
+)
+
+func noDetectors(*modutils.ModuleInfo) []ast.Visitor { return nil }
+
+func TestScanParseError(t *testing.T) {
+	root := t.TempDir()
+	bad := filepath.Join(root, "broken.go")
+	writeFile(t, bad, "package broken\n\nfunc Broken( {\n")
+
+	issues, err := ScanDirectory(root, noDetectors)
+	if err != nil {
+		t.Fatalf("expected the scan to keep going, got %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected one ParseError issue, got %+v", issues)
+	}
+	if is := issues[0]; is.Rule != "ParseError" || is.Severity != "error" || is.File != bad || is.Line != 3 {
+		t.Fatalf("unexpected issue: %+v", is)
+	}
+
+	_, parseErrs, err := ScanTargetsPartial([]string{root}, noDetectors, Options{})
+	if err != nil || len(parseErrs) != 1 || parseErrs[0].File != bad {
+		t.Fatalf("expected a ParseError for %s, got %v, %v", bad, parseErrs, err)
+	}
+}
+
+func TestScanContinuesPastParseError(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/app\n")
+	writeFile(t, filepath.Join(root, "a_broken.go"), "package app\n\nfunc Broken( {\n")
+	writeFile(t, filepath.Join(root, "workflow.go"), `package app
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func ClockWorkflow(ctx workflow.Context) error {
+	_ = time.Now()
+	return nil
+}
+`)
+	factory := func(mi *modutils.ModuleInfo) []ast.Visitor {
+		return []ast.Visitor{detectors.NewFuncCallDetector([]config.FunctionRule{{Rule: "TimeUsage", Package: "time", Functions: []string{"Now"}, Severity: "error"}}, nil, nil, mi)}
+	}
+
+	issues, err := ScanDirectory(root, factory)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	var rules []string
+	for _, is := range issues {
+		rules = append(rules, filepath.Base(is.File)+":"+is.Rule)
+	}
+	if want := []string{"a_broken.go:ParseError", "workflow.go:TimeUsage"}; !reflect.DeepEqual(rules, want) {
+		t.Fatalf("got %v, want %v", rules, want)
+	}
+}
+
+func TestScanTargetNotFound(t *testing.T) {
+	_, err := ScanFile(filepath.Join(t.TempDir(), "missing.go"), noDetectors)
+	if !errors.Is(err, ErrTargetNotFound) {
+		t.Fatalf("expected ErrTargetNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package fix
+
+import (
+	"os"
+	"sort"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+// BackupSuffix is appended to a file's name to keep its original content
+// before fixes are written.
+const BackupSuffix = ".orig"
+
+// Apply rewrites files on disk with every high-confidence suggested fix,
+// keeping a backup of each original next to it. Imports left unused by the
+// fixes are removed. It returns the rewritten files and the number of fix
+// edits written, after duplicate and overlapping edits are dropped;
+// low-confidence fixes are left as suggestions.
+func Apply(issues []detectors.Issue) ([]string, int, error) {
+	var confident []detectors.Issue
+	for _, is := range issues {
+		if is.SuggestedFix != nil && is.SuggestedFix.Confidence == detectors.FixConfidenceHigh {
+			confident = append(confident, is)
+		}
+	}
+
+	byFile := EditsByFile(confident)
+	files := make([]string, 0, len(byFile))
+	for f := range byFile {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+
+	applied := 0
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return nil, 0, err
+		}
+		src, err := os.ReadFile(f)
+		if err != nil {
+			return nil, 0, err
+		}
+		edits, err := normalize(src, byFile[f])
+		if err != nil {
+			return nil, 0, err
+		}
+		imports, err := importEdits(f, src, edits)
+		if err != nil {
+			return nil, 0, err
+		}
+		fixed, err := ApplyEdits(src, append(edits, imports...))
+		if err != nil {
+			return nil, 0, err
+		}
+		applied += len(edits)
+		if err := os.WriteFile(f+BackupSuffix, src, info.Mode().Perm()); err != nil {
+			return nil, 0, err
+		}
+		if err := os.WriteFile(f, fixed, info.Mode().Perm()); err != nil {
+			return nil, 0, err
+		}
+	}
+	return files, applied, nil
+}
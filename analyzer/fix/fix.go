@@ -0,0 +1,202 @@
+// Package fix turns the SuggestedFix edits attached to issues into unified
+// diffs and, for high-confidence fixes, rewritten source files.
+package fix
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+// contextLines is the number of unchanged lines shown around each hunk.
+const contextLines = 3
+
+// EditsByFile collects the edits of all suggested fixes, grouped by file.
+func EditsByFile(issues []detectors.Issue) map[string][]detectors.TextEdit {
+	byFile := map[string][]detectors.TextEdit{}
+	for _, is := range issues {
+		if is.SuggestedFix == nil {
+			continue
+		}
+		for _, e := range is.SuggestedFix.Edits {
+			byFile[e.File] = append(byFile[e.File], e)
+		}
+	}
+	return byFile
+}
+
+// Diff renders a unified diff for every file touched by a suggested fix,
+// including the removal of imports the fixes leave unused.
+func Diff(issues []detectors.Issue) (string, error) {
+	byFile := EditsByFile(issues)
+	files := make([]string, 0, len(byFile))
+	for f := range byFile {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+
+	var b strings.Builder
+	for _, f := range files {
+		src, err := os.ReadFile(f)
+		if err != nil {
+			return "", err
+		}
+		imports, err := importEdits(f, src, byFile[f])
+		if err != nil {
+			return "", err
+		}
+		d, err := UnifiedDiff(f, src, append(byFile[f], imports...))
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(d)
+	}
+	return b.String(), nil
+}
+
+// normalize sorts edits by offset and drops duplicates and edits overlapping an earlier one.
+func normalize(src []byte, edits []detectors.TextEdit) ([]detectors.TextEdit, error) {
+	sorted := append([]detectors.TextEdit(nil), edits...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start < sorted[j].Start })
+
+	var out []detectors.TextEdit
+	for _, e := range sorted {
+		if e.Start < 0 || e.End < e.Start || e.End > len(src) {
+			return nil, fmt.Errorf("edit [%d,%d) out of range for %s", e.Start, e.End, e.File)
+		}
+		if n := len(out); n > 0 {
+			last := out[n-1]
+			if last == e {
+				continue
+			}
+			if e.Start < last.End {
+				continue // conflicting fix; keep the first one
+			}
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// ApplyEdits returns src with the edits applied. Edits are applied back to
+// front so earlier offsets stay valid.
+func ApplyEdits(src []byte, edits []detectors.TextEdit) ([]byte, error) {
+	sorted, err := normalize(src, edits)
+	if err != nil {
+		return nil, err
+	}
+	out := append([]byte(nil), src...)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		e := sorted[i]
+		out = append(out[:e.Start], append([]byte(e.NewText), out[e.End:]...)...)
+	}
+	return out, nil
+}
+
+// UnifiedDiff renders the edits against src as a unified diff.
+func UnifiedDiff(filename string, src []byte, edits []detectors.TextEdit) (string, error) {
+	sorted, err := normalize(src, edits)
+	if err != nil {
+		return "", err
+	}
+	if len(sorted) == 0 {
+		return "", nil
+	}
+
+	// Line start offsets; lineStarts[i] is the offset of line i (0-based).
+	lineStarts := []int{0}
+	for i, c := range src {
+		if c == '\n' && i+1 < len(src) {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	lineOf := func(off int) int {
+		return sort.Search(len(lineStarts), func(i int) bool { return lineStarts[i] > off }) - 1
+	}
+	lineEnd := func(line int) int {
+		if line+1 < len(lineStarts) {
+			return lineStarts[line+1]
+		}
+		return len(src)
+	}
+
+	type hunk struct {
+		first, last int // 0-based inclusive line range, including context
+		edits       []detectors.TextEdit
+	}
+	var hunks []hunk
+	for _, e := range sorted {
+		endLine := lineOf(e.Start)
+		if e.End > e.Start {
+			endLine = lineOf(e.End - 1)
+		}
+		first := max(lineOf(e.Start)-contextLines, 0)
+		last := min(endLine+contextLines, len(lineStarts)-1)
+		if n := len(hunks); n > 0 && first <= hunks[n-1].last+1 {
+			hunks[n-1].last = max(hunks[n-1].last, last)
+			hunks[n-1].edits = append(hunks[n-1].edits, e)
+			continue
+		}
+		hunks = append(hunks, hunk{first: first, last: last, edits: []detectors.TextEdit{e}})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", filename, filename)
+	delta := 0
+	for _, h := range hunks {
+		start, end := lineStarts[h.first], lineEnd(h.last)
+		oldText := string(src[start:end])
+
+		var newText strings.Builder
+		cursor := start
+		for _, e := range h.edits {
+			newText.Write(src[cursor:e.Start])
+			newText.WriteString(e.NewText)
+			cursor = e.End
+		}
+		newText.Write(src[cursor:end])
+
+		oldLines := splitLines(oldText)
+		newLines := splitLines(newText.String())
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", h.first+1, len(oldLines), h.first+1+delta, len(newLines))
+		writeHunkBody(&b, oldLines, newLines)
+		delta += len(newLines) - len(oldLines)
+	}
+	return b.String(), nil
+}
+
+// writeHunkBody emits shared leading/trailing lines as context and the rest as -/+ lines.
+func writeHunkBody(b *strings.Builder, oldLines, newLines []string) {
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+	for _, l := range oldLines[:prefix] {
+		b.WriteString(" " + l + "\n")
+	}
+	for _, l := range oldLines[prefix : len(oldLines)-suffix] {
+		b.WriteString("-" + l + "\n")
+	}
+	for _, l := range newLines[prefix : len(newLines)-suffix] {
+		b.WriteString("+" + l + "\n")
+	}
+	for _, l := range oldLines[len(oldLines)-suffix:] {
+		b.WriteString(" " + l + "\n")
+	}
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
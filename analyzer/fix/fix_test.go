@@ -0,0 +1,191 @@
+package fix
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer"
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+	"github.com/afony10/cadence-workflow-linter/analyzer/modutils"
+	"github.com/afony10/cadence-workflow-linter/config"
+)
+
+func timeFactory(t *testing.T) func(*modutils.ModuleInfo) []ast.Visitor {
+	t.Helper()
+	rules, err := config.LoadRules("../../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+	return func(moduleInfo *modutils.ModuleInfo) []ast.Visitor {
+		return []ast.Visitor{
+			detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, moduleInfo),
+		}
+	}
+}
+
+func TestDiffTimeNow(t *testing.T) {
+	factory := timeFactory(t)
+	issues, err := analyzer.ScanFile("../../testdata/time_violation.go", factory)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+
+	diff, err := Diff(issues)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+
+	want := `--- a/../../testdata/time_violation.go
++++ b/../../testdata/time_violation.go
+@@ -11,6 +11,6 @@
+ }
+ 
+ func MyWorkflow(ctx workflow.Context) error {
+-	_ = time.Now() // should be flagged
++	_ = workflow.Now(ctx) // should be flagged
+ 	return nil
+ }
+`
+	if diff != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", diff, want)
+	}
+}
+
+func TestApplyEditsBackToFront(t *testing.T) {
+	src := []byte("a := time.Now()\nb := time.Now()\n")
+	edits := []detectors.TextEdit{
+		{Start: 21, End: 31, NewText: "workflow.Now(ctx)"},
+		{Start: 5, End: 15, NewText: "workflow.Now(ctx)"},
+	}
+	got, err := ApplyEdits(src, edits)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	want := "a := workflow.Now(ctx)\nb := workflow.Now(ctx)\n"
+	if string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if !strings.Contains(string(src), "time.Now") {
+		t.Fatal("ApplyEdits must not modify its input")
+	}
+}
+
+func TestApplyTimeNowFix(t *testing.T) {
+	factory := timeFactory(t)
+
+	src, err := os.ReadFile("../../testdata/time_violation.go")
+	if err != nil {
+		t.Fatalf("read testdata: %v", err)
+	}
+	target := filepath.Join(t.TempDir(), "time_violation.go")
+	if err := os.WriteFile(target, src, 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	issues, err := analyzer.ScanFile(target, factory)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if len(issues) == 0 {
+		t.Fatal("expected a TimeUsage issue before applying fixes")
+	}
+
+	files, applied, err := Apply(issues)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if applied != 1 || len(files) != 1 {
+		t.Fatalf("expected 1 fix in 1 file, got %d fixes in %v", applied, files)
+	}
+	if backup, err := os.ReadFile(target + BackupSuffix); err != nil || string(backup) != string(src) {
+		t.Fatalf("expected backup with original content, err=%v", err)
+	}
+
+	remaining, err := analyzer.ScanFile(target, factory)
+	if err != nil {
+		t.Fatalf("re-scan: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Fatalf("expected no issues after applying fixes, got %+v", remaining)
+	}
+}
+
+func TestApplyRemovesUnusedImports(t *testing.T) {
+	factory := timeFactory(t)
+	src := `package app
+
+import (
+	"fmt"
+	"time"
+
+	wf "go.uber.org/cadence/workflow"
+	"go.uber.org/cadence/workflow"
+)
+
+func ClockWorkflow(ctx workflow.Context) error {
+	_ = time.Now()
+	fmt.Sprint(wf.GetInfo(ctx))
+	return nil
+}
+`
+	target := filepath.Join(t.TempDir(), "clock.go")
+	if err := os.WriteFile(target, []byte(src), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	issues, err := analyzer.ScanFile(target, factory)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	// The same fix reported twice is written once.
+	issues = append(issues, issues...)
+
+	_, applied, err := Apply(issues)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if applied != 1 {
+		t.Fatalf("expected 1 edit written, got %d", applied)
+	}
+	got, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	want := `package app
+
+import (
+	"fmt"
+
+	wf "go.uber.org/cadence/workflow"
+	"go.uber.org/cadence/workflow"
+)
+
+func ClockWorkflow(ctx workflow.Context) error {
+	_ = workflow.Now(ctx)
+	fmt.Sprint(wf.GetInfo(ctx))
+	return nil
+}
+`
+	if string(got) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestImportEditsDropsWholeDecl(t *testing.T) {
+	src := []byte("package app\n\nimport \"time\"\n\nvar _ = time.Now()\n")
+	start := strings.Index(string(src), "time.Now()")
+	edits := []detectors.TextEdit{{File: "app.go", Start: start, End: start + len("time.Now()"), NewText: "0"}}
+	imports, err := importEdits("app.go", src, edits)
+	if err != nil {
+		t.Fatalf("import edits: %v", err)
+	}
+	got, err := ApplyEdits(src, append(edits, imports...))
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if want := "package app\n\nvar _ = 0\n"; string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
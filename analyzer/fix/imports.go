@@ -0,0 +1,137 @@
+package fix
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"strconv"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+// importEdits returns edits deleting the imports of src that the file uses
+// before edits are applied but not after, so a fix that removes the last use
+// of a package, like time.Now() -> workflow.Now(ctx), still compiles. Imports
+// that were already unused, and files that don't parse, are left alone.
+func importEdits(filename string, src []byte, edits []detectors.TextEdit) ([]detectors.TextEdit, error) {
+	fixed, err := ApplyEdits(src, edits)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	before, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, nil
+	}
+	after, err := parser.ParseFile(token.NewFileSet(), filename, fixed, 0)
+	if err != nil {
+		return nil, nil
+	}
+	usedBefore, usedAfter := packageRefs(before), packageRefs(after)
+
+	var out []detectors.TextEdit
+	for _, decl := range before.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		var drop []*ast.ImportSpec
+		for _, spec := range gen.Specs {
+			imp := spec.(*ast.ImportSpec)
+			if name := importName(imp); usedBefore[name] && !usedAfter[name] {
+				drop = append(drop, imp)
+			}
+		}
+		if len(drop) == 0 {
+			continue
+		}
+		if len(drop) == len(gen.Specs) {
+			start, end := lineRange(src, fset.Position(gen.Pos()).Offset, fset.Position(gen.End()).Offset)
+			if blankLineAt(src, end) {
+				_, end = lineRange(src, end, end)
+			}
+			out = append(out, detectors.TextEdit{File: filename, Start: start, End: end})
+			continue
+		}
+		for _, imp := range drop {
+			from := imp.Pos()
+			if imp.Doc != nil {
+				from = imp.Doc.Pos()
+			}
+			to := imp.End()
+			if imp.Comment != nil {
+				to = imp.Comment.End()
+			}
+			start, end := lineRange(src, fset.Position(from).Offset, fset.Position(to).Offset)
+			// Don't leave a blank line at the top or bottom of the group.
+			if imp == gen.Specs[0] && blankLineAt(src, end) {
+				_, end = lineRange(src, end, end)
+			} else if imp == gen.Specs[len(gen.Specs)-1] && start > 0 && blankLineAt(src, lineStart(src, start-1)) {
+				start = lineStart(src, start-1)
+			}
+			out = append(out, detectors.TextEdit{File: filename, Start: start, End: end})
+		}
+	}
+	return out, nil
+}
+
+// packageRefs returns the identifiers used as the package of a selector that
+// doesn't resolve to a local declaration, i.e. the imports the file uses.
+func packageRefs(f *ast.File) map[string]bool {
+	refs := map[string]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil {
+				refs[id.Name] = true
+			}
+		}
+		return true
+	})
+	return refs
+}
+
+// importName returns the name an import is referred to by. Blank and dot
+// imports return "", which never matches a reference.
+func importName(imp *ast.ImportSpec) string {
+	if imp.Name != nil {
+		if imp.Name.Name == "_" || imp.Name.Name == "." {
+			return ""
+		}
+		return imp.Name.Name
+	}
+	p, err := strconv.Unquote(imp.Path.Value)
+	if err != nil {
+		return ""
+	}
+	return path.Base(p)
+}
+
+// lineRange widens [start, end) to whole lines, including the trailing newline.
+func lineRange(src []byte, start, end int) (int, int) {
+	start = lineStart(src, start)
+	for end < len(src) && src[end] != '\n' {
+		end++
+	}
+	if end < len(src) {
+		end++
+	}
+	return start, end
+}
+
+func lineStart(src []byte, off int) int {
+	for off > 0 && src[off-1] != '\n' {
+		off--
+	}
+	return off
+}
+
+// blankLineAt reports whether the line starting at off holds only whitespace.
+func blankLineAt(src []byte, off int) bool {
+	for ; off < len(src) && src[off] != '\n'; off++ {
+		if src[off] != ' ' && src[off] != '\t' && src[off] != '\r' {
+			return false
+		}
+	}
+	return off < len(src)
+}
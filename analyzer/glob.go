@@ -0,0 +1,39 @@
+package analyzer
+
+import (
+	"path"
+	"strings"
+)
+
+// matchGlob reports whether the slash-separated relative path rel matches
+// pattern. Segments are matched with path.Match, and a "**" segment matches
+// any number of segments, including none. A pattern without a slash matches
+// the last element of rel, so "*_gen.go" excludes generated files anywhere.
+func matchGlob(pattern, rel string) bool {
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(rel))
+		return ok
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(rel, "/"))
+}
+
+func matchSegments(pattern, segs []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segs); i++ {
+				if matchSegments(pattern[1:], segs[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segs) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segs[0]); !ok {
+			return false
+		}
+		pattern, segs = pattern[1:], segs[1:]
+	}
+	return len(segs) == 0
+}
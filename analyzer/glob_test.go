@@ -0,0 +1,26 @@
+package analyzer
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	cases := []struct {
+		pattern, rel string
+		want         bool
+	}{
+		{"*_gen.go", "api_gen.go", true},
+		{"*_gen.go", "internal/api/types_gen.go", true},
+		{"*_gen.go", "gen.go", false},
+		{"**/mocks/**", "mocks/client.go", true},
+		{"**/mocks/**", "svc/mocks/deep/client.go", true},
+		{"**/mocks/**", "svc/mocks.go", false},
+		{"internal/*/fake.go", "internal/db/fake.go", true},
+		{"internal/*/fake.go", "internal/db/x/fake.go", false},
+		{"**/*.pb.go", "proto/v1/order.pb.go", true},
+		{"proto/**", "proto", true},
+	}
+	for _, c := range cases {
+		if got := matchGlob(c.pattern, c.rel); got != c.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", c.pattern, c.rel, got, c.want)
+		}
+	}
+}
@@ -6,12 +6,12 @@ import (
 	"strings"
 )
 
-type Edge struct{ Caller, Callee string }
+type Edge struct{ Caller, Callee FuncID }
 
-// BuildEdges inspects one file and returns call edges (canonicalized).
-// callerName should be the canonical "pkgPath.Func", passed per function.
+// BuildEdges inspects one file and returns call edges between canonical function IDs.
 func BuildEdges(file *ast.File, pkgPath string, importMap map[string]string) []Edge {
 	var edges []Edge
+	activities := ActivityLiterals(file, importMap)
 
 	ast.Inspect(file, func(n ast.Node) bool {
 		fn, ok := n.(*ast.FuncDecl)
@@ -19,9 +19,16 @@ func BuildEdges(file *ast.File, pkgPath string, importMap map[string]string) []E
 			return true
 		}
 
-		caller := canonical(pkgPath, fn.Name.Name)
+		caller := canonical(pkgPath, FuncDeclName(fn))
+		locals := receiverTypes(fn)
 
 		ast.Inspect(fn.Body, func(m ast.Node) bool {
+			if lit, ok := m.(*ast.FuncLit); ok && activities[lit] {
+				// runs as an activity, not as part of the caller
+				return false
+			}
+			trackLocalTypes(m, locals)
+
 			call, ok := m.(*ast.CallExpr)
 			if !ok {
 				return true
@@ -37,6 +44,14 @@ func BuildEdges(file *ast.File, pkgPath string, importMap map[string]string) []E
 			// alias.Func()
 			if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
 				if recv, ok := sel.X.(*ast.Ident); ok {
+					// value.Method() on a local struct value or the receiver
+					if typeName, ok := locals[recv.Name]; ok {
+						edges = append(edges, Edge{
+							Caller: caller,
+							Callee: canonical(pkgPath, MethodName(typeName, sel.Sel.Name)),
+						})
+						return true
+					}
 					alias := recv.Name
 					imp := importMap[alias]
 					if imp == "" {
@@ -57,11 +72,149 @@ func BuildEdges(file *ast.File, pkgPath string, importMap map[string]string) []E
 	return edges
 }
 
-func canonical(pkgOrImportPath, funcName string) string {
-	// ensure pkg path is something like "github.com/me/proj/pkg" or "time"
-	p := strings.TrimSpace(pkgOrImportPath)
-	if p == "" {
-		p = "local"
+// ActivityLiterals returns the function literals in node passed as the
+// activity of a workflow.ExecuteActivity or ExecuteLocalActivity call, with the
+// workflow package resolved through importMap. Their bodies run as activities,
+// not as part of the function that declares them. Other literals, whether
+// invoked immediately, stored in a variable or passed to workflow.Go, do.
+func ActivityLiterals(node ast.Node, importMap map[string]string) map[*ast.FuncLit]bool {
+	lits := map[*ast.FuncLit]bool{}
+	ast.Inspect(node, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) < 2 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || (sel.Sel.Name != "ExecuteActivity" && sel.Sel.Name != "ExecuteLocalActivity") {
+			return true
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		if pkg := importMap[ident.Name]; pkg != "workflow" && !strings.HasSuffix(pkg, "/workflow") {
+			return true
+		}
+		if lit, ok := call.Args[1].(*ast.FuncLit); ok {
+			lits[lit] = true
+		}
+		return true
+	})
+	return lits
+}
+
+// FuncDeclName returns the package-relative name of a function declaration:
+// "Func" for functions and "(Type).Method" for methods (pointer receivers included).
+func FuncDeclName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	if typeName := namedType(fn.Recv.List[0].Type); typeName != "" {
+		return MethodName(typeName, fn.Name.Name)
+	}
+	return fn.Name.Name
+}
+
+// MethodName builds the package-relative name of a method on typeName.
+func MethodName(typeName, method string) string {
+	return "(" + typeName + ")." + method
+}
+
+// namedType returns T for the local type expressions T, *T, T[P] and *T[P].
+func namedType(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return namedType(t.X)
+	case *ast.IndexExpr:
+		return namedType(t.X)
+	case *ast.IndexListExpr:
+		return namedType(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// receiverTypes seeds a function's local type table with its receiver and
+// parameters whose type is a package-local named type.
+func receiverTypes(fn *ast.FuncDecl) map[string]string {
+	locals := map[string]string{}
+	var fields []*ast.Field
+	if fn.Recv != nil {
+		fields = append(fields, fn.Recv.List...)
 	}
-	return p + "." + funcName
+	if fn.Type.Params != nil {
+		fields = append(fields, fn.Type.Params.List...)
+	}
+	for _, field := range fields {
+		if typeName := namedType(field.Type); typeName != "" && !isPredeclared(typeName) {
+			for _, name := range field.Names {
+				locals[name.Name] = typeName
+			}
+		}
+	}
+	return locals
+}
+
+// trackLocalTypes records best-effort types of local variables declared as
+// `x := T{}`, `x := &T{}`, `x := new(T)` or `var x T`.
+func trackLocalTypes(n ast.Node, locals map[string]string) {
+	switch s := n.(type) {
+	case *ast.AssignStmt:
+		if len(s.Lhs) != len(s.Rhs) {
+			return
+		}
+		for i, lhs := range s.Lhs {
+			if ident, ok := lhs.(*ast.Ident); ok {
+				if typeName := valueTypeName(s.Rhs[i]); typeName != "" && !isPredeclared(typeName) {
+					locals[ident.Name] = typeName
+				}
+			}
+		}
+	case *ast.ValueSpec:
+		for i, name := range s.Names {
+			typeName := ""
+			if s.Type != nil {
+				typeName = namedType(s.Type)
+			} else if i < len(s.Values) {
+				typeName = valueTypeName(s.Values[i])
+			}
+			if typeName != "" && !isPredeclared(typeName) {
+				locals[name.Name] = typeName
+			}
+		}
+	}
+}
+
+// valueTypeName returns T for T{}, &T{} and new(T) where T is a local type.
+func valueTypeName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.UnaryExpr:
+		return valueTypeName(e.X)
+	case *ast.CompositeLit:
+		if e.Type != nil {
+			return namedType(e.Type)
+		}
+	case *ast.CallExpr:
+		if ident, ok := e.Fun.(*ast.Ident); ok && ident.Name == "new" && len(e.Args) == 1 {
+			return namedType(e.Args[0])
+		}
+	}
+	return ""
+}
+
+func isPredeclared(name string) bool {
+	switch name {
+	case "bool", "byte", "complex64", "complex128", "error", "float32", "float64",
+		"int", "int8", "int16", "int32", "int64", "rune", "string",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "any":
+		return true
+	}
+	return false
+}
+
+// canonical identifies funcName within a package path such as
+// "github.com/me/proj/pkg" or "time".
+func canonical(pkgOrImportPath, funcName string) FuncID {
+	return NewFuncID(pkgOrImportPath, funcName)
 }
@@ -0,0 +1,58 @@
+package registry
+
+import "strings"
+
+// FuncID identifies a function or method without relying on where a dotted
+// package path ends. Recv is the receiver type name for methods, "" otherwise.
+type FuncID struct {
+	Pkg  string
+	Recv string
+	Name string
+}
+
+// NewFuncID builds the ID of a function in pkgPath from its package-relative
+// name as returned by FuncDeclName ("Func" or "(Type).Method").
+func NewFuncID(pkgPath, funcName string) FuncID {
+	pkgPath = strings.TrimSpace(pkgPath)
+	if pkgPath == "" {
+		pkgPath = "local"
+	}
+	if strings.HasPrefix(funcName, "(") {
+		if i := strings.Index(funcName, ")."); i > 0 {
+			return FuncID{Pkg: pkgPath, Recv: funcName[1:i], Name: funcName[i+2:]}
+		}
+	}
+	return FuncID{Pkg: pkgPath, Name: funcName}
+}
+
+// ParseFuncID parses a canonical display name ("pkg/path.Func" or
+// "pkg/path.(Type).Method") back into a FuncID. Package paths may contain
+// dots, but function and type names can't, so the last separator wins.
+func ParseFuncID(canonicalName string) FuncID {
+	if i := strings.Index(canonicalName, ".("); i >= 0 {
+		return NewFuncID(canonicalName[:i], canonicalName[i+1:])
+	}
+	if i := strings.LastIndex(canonicalName, "."); i >= 0 && i > strings.LastIndex(canonicalName, "/") {
+		return NewFuncID(canonicalName[:i], canonicalName[i+1:])
+	}
+	return NewFuncID("", canonicalName)
+}
+
+// LocalName returns the package-relative name: "Func" or "(Type).Method".
+func (id FuncID) LocalName() string {
+	if id.Recv != "" {
+		return MethodName(id.Recv, id.Name)
+	}
+	return id.Name
+}
+
+// String returns the canonical display name, e.g. "go.uber.org/cadence/workflow.Sleep".
+func (id FuncID) String() string {
+	return id.Pkg + "." + id.LocalName()
+}
+
+// Matches reports whether name refers to id, either canonically
+// ("pkg/path.Func") or by its package-relative name ("Func", "(Type).Method").
+func (id FuncID) Matches(name string) bool {
+	return name == id.String() || name == id.LocalName()
+}
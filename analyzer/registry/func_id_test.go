@@ -0,0 +1,121 @@
+package registry
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestFuncIDRoundTrip(t *testing.T) {
+	cases := []struct {
+		name string
+		id   FuncID
+	}{
+		{"go.uber.org/cadence/workflow.Sleep", FuncID{Pkg: "go.uber.org/cadence/workflow", Name: "Sleep"}},
+		{"example.com/app.(Client).Do", FuncID{Pkg: "example.com/app", Recv: "Client", Name: "Do"}},
+		{"time.Now", FuncID{Pkg: "time", Name: "Now"}},
+		{"gopkg.in/yaml.v3.Marshal", FuncID{Pkg: "gopkg.in/yaml.v3", Name: "Marshal"}},
+	}
+	for _, c := range cases {
+		if got := ParseFuncID(c.name); got != c.id {
+			t.Errorf("ParseFuncID(%q) = %+v, want %+v", c.name, got, c.id)
+		}
+		if got := c.id.String(); got != c.name {
+			t.Errorf("%+v.String() = %q, want %q", c.id, got, c.name)
+		}
+	}
+}
+
+func TestLookupsAcrossFiles(t *testing.T) {
+	const pkg = "example.com/svc.v2/orders"
+	srcs := []string{`package orders
+
+import "go.uber.org/cadence/workflow"
+
+func OrderWorkflow(ctx workflow.Context) error {
+	b := &billing{}
+	b.charge()
+	return nil
+}
+`, `package orders
+
+type billing struct{}
+
+func (b *billing) charge() { audit() }
+
+func audit() {}
+`}
+
+	wr := NewWorkflowRegistry()
+	fset := token.NewFileSet()
+	for i, src := range srcs {
+		f, err := parser.ParseFile(fset, "", src, 0)
+		if err != nil {
+			t.Fatalf("parse file %d: %v", i, err)
+		}
+		wr.ProcessFile(f, pkg, importMap(f))
+	}
+
+	wf := FuncID{Pkg: pkg, Name: "OrderWorkflow"}
+	charge := FuncID{Pkg: pkg, Recv: "billing", Name: "charge"}
+	audit := FuncID{Pkg: pkg, Name: "audit"}
+	if !wr.WorkflowFuncs[wf] {
+		t.Fatalf("expected %s to be a workflow, got %v", wf, wr.WorkflowFuncs)
+	}
+	for _, id := range []FuncID{charge, audit} {
+		if !wr.IsWorkflowReachable(id) {
+			t.Errorf("expected %s to be reachable", id)
+		}
+	}
+	path := wr.CallPathTo(audit)
+	if len(path) != 3 || path[0] != wf || path[1] != charge || path[2] != audit {
+		t.Errorf("unexpected call path %v", path)
+	}
+}
+
+func importMap(f *ast.File) map[string]string {
+	m := map[string]string{}
+	for _, imp := range f.Imports {
+		p := strings.Trim(imp.Path.Value, `"`)
+		if imp.Name != nil {
+			m[imp.Name.Name] = p
+			continue
+		}
+		m[path.Base(p)] = p
+	}
+	return m
+}
+
+func TestActivityLiterals(t *testing.T) {
+	src := `package app
+
+import (
+	stdctx "context"
+
+	cwf "go.uber.org/cadence/workflow"
+)
+
+func AppWorkflow(ctx cwf.Context) error {
+	local := func(c stdctx.Context) error { return nil }
+	_ = local(nil)
+	return cwf.ExecuteLocalActivity(ctx, func(c stdctx.Context) error { return nil }).Get(ctx, nil)
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	lits := ActivityLiterals(f, importMap(f))
+	if len(lits) != 1 {
+		t.Fatalf("expected only the ExecuteLocalActivity literal, got %d", len(lits))
+	}
+	for lit := range lits {
+		if line := fset.Position(lit.Pos()).Line; line != 12 {
+			t.Errorf("expected the literal on line 12, got line %d", line)
+		}
+	}
+}
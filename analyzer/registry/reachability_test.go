@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// syntheticRegistry builds a call graph of n functions with a few workflow
+// roots, activities and opaque excluded functions, plus cycles.
+func syntheticRegistry(tb testing.TB, n int) (*WorkflowRegistry, []FuncID) {
+	tb.Helper()
+	rng := rand.New(rand.NewSource(1))
+	ids := make([]FuncID, n)
+	for i := range ids {
+		ids[i] = NewFuncID(fmt.Sprintf("example.com/app/p%d", i%20), fmt.Sprintf("F%d", i))
+	}
+
+	wr := NewWorkflowRegistry()
+	if err := wr.ExcludeFunctions([]string{"example.com/app/p7.*"}, true); err != nil {
+		tb.Fatalf("exclude: %v", err)
+	}
+	var edges []Edge
+	for i, id := range ids {
+		switch {
+		case i%100 == 0:
+			wr.MarkWorkflow(id.Pkg, id.Name)
+		case i%37 == 0:
+			wr.MarkActivity(id.Pkg, id.Name)
+		}
+		for k := 0; k < 3; k++ {
+			edges = append(edges, Edge{Caller: id, Callee: ids[rng.Intn(n)]})
+		}
+	}
+	wr.AddEdges(edges)
+	return wr, ids
+}
+
+func TestReachabilityCacheMatchesSearch(t *testing.T) {
+	wr, ids := syntheticRegistry(t, 3000)
+	want := make([]bool, len(ids))
+	for i, id := range ids {
+		want[i] = wr.IsWorkflowReachable(id)
+	}
+
+	wr.BuildReachabilityCache()
+	reachable := 0
+	for i, id := range ids {
+		got := wr.IsWorkflowReachable(id)
+		if got != want[i] {
+			t.Fatalf("%s: cached %v, uncached %v", id, got, want[i])
+		}
+		if got {
+			reachable++
+		}
+	}
+	if reachable == 0 || reachable == len(ids) {
+		t.Fatalf("synthetic graph should mix reachable and unreachable functions, got %d of %d", reachable, len(ids))
+	}
+
+	// Changing the graph drops the cache instead of serving stale answers.
+	orphan := NewFuncID("example.com/app/orphan", "F")
+	if wr.IsWorkflowReachable(orphan) {
+		t.Fatal("orphan should not be reachable yet")
+	}
+	wr.AddEdges([]Edge{{Caller: ids[0], Callee: orphan}})
+	if !wr.IsWorkflowReachable(orphan) {
+		t.Fatal("expected a new edge from a workflow to invalidate the cache")
+	}
+}
+
+func BenchmarkIsWorkflowReachable(b *testing.B) {
+	for _, cached := range []bool{false, true} {
+		b.Run(fmt.Sprintf("cached=%v", cached), func(b *testing.B) {
+			wr, ids := syntheticRegistry(b, 3000)
+			if cached {
+				wr.BuildReachabilityCache()
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				wr.IsWorkflowReachable(ids[i%len(ids)])
+			}
+		})
+	}
+}
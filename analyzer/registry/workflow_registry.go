@@ -2,19 +2,106 @@ package registry
 
 import (
 	"go/ast"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
 )
 
 // WorkflowRegistry tracks which functions are workflows, which are activities,
 // and a call graph (who calls who). It also provides reachability and call-stack helpers.
 type WorkflowRegistry struct {
-	WorkflowFuncs map[string]bool     // functions that take workflow.Context (canonical: "pkgPath.Func")
-	ActivityFuncs map[string]bool     // functions that take context.Context (canonical: "pkgPath.Func")
-	CallGraph     map[string][]string // caller -> []callees (canonical names)
+	WorkflowFuncs map[FuncID]bool               // functions that take workflow.Context
+	ActivityFuncs map[FuncID]bool               // functions that take context.Context
+	CallGraph     map[FuncID][]FuncID           // caller -> []callees
+	Registered    map[FuncID]bool               // functions passed to a workflow registration call
+	PackageVars   map[string]map[string]bool    // package path -> names of its package-level vars
+	VarDecls      map[string]map[string]VarDecl // package path -> declarations of its package-level vars
+	ActivityNames map[string]bool               // names activities are registered under
+
+	excluded       []*regexp.Regexp // canonical-name globs excluded from analysis
+	opaqueExcluded bool             // don't follow calls out of excluded functions
+	contextPkgs    map[string]bool  // import paths whose Context type implies workflow code
+	reachable      map[FuncID]bool  // cached callees of workflows; nil until BuildReachabilityCache
+	opaqueActivity bool             // an activity was registered under a name that can't be determined
+}
+
+// VarDecl is the declaration of a package-level variable, kept with the import
+// names of its file so detectors can tell its type from other files.
+type VarDecl struct {
+	Type      ast.Expr          // declared type, or nil
+	Value     ast.Expr          // initializer, or nil
+	ImportMap map[string]string // import names of the declaring file
+}
+
+// SetWorkflowContextPackages makes functions taking pkg.Context, for any of
+// the given import paths, count as workflows. Use it for workflow-only
+// wrapper packages around workflow.Context. Call before ProcessFile.
+func (wr *WorkflowRegistry) SetWorkflowContextPackages(pkgs []string) {
+	wr.contextPkgs = map[string]bool{}
+	for _, p := range pkgs {
+		wr.contextPkgs[p] = true
+	}
+}
+
+// ExcludeFunctions marks functions matching the canonical-name globs as
+// excluded: they are never considered workflow-reachable, so detectors skip
+// issues inside them. In glob patterns '*' matches any run of characters
+// (including '/' and '.') and '?' matches one. With opaque set, reachability
+// also stops at excluded functions instead of continuing into their callees.
+func (wr *WorkflowRegistry) ExcludeFunctions(patterns []string, opaque bool) error {
+	for _, p := range patterns {
+		re, err := globToRegexp(p)
+		if err != nil {
+			return err
+		}
+		wr.excluded = append(wr.excluded, re)
+	}
+	wr.opaqueExcluded = opaque
+	wr.reachable = nil
+	return nil
+}
+
+// IsExcluded reports whether a function's canonical name matches an exclude pattern.
+func (wr *WorkflowRegistry) IsExcluded(id FuncID) bool {
+	if len(wr.excluded) == 0 {
+		return false
+	}
+	name := id.String()
+	for _, re := range wr.excluded {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isOpaque reports whether reachability must not continue through fn.
+func (wr *WorkflowRegistry) isOpaque(fn FuncID) bool {
+	return wr.opaqueExcluded && wr.IsExcluded(fn)
+}
+
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
 }
 
 // MarkWorkflow marks a function as a workflow using canonical naming
 func (wr *WorkflowRegistry) MarkWorkflow(pkgPath, funcName string) {
 	wr.WorkflowFuncs[canonical(pkgPath, funcName)] = true
+	wr.reachable = nil
 }
 
 // MarkActivity marks a function as an activity using canonical naming
@@ -24,66 +111,269 @@ func (wr *WorkflowRegistry) MarkActivity(pkgPath, funcName string) {
 
 // AddEdges adds call graph edges to the registry
 func (wr *WorkflowRegistry) AddEdges(edges []Edge) {
+	wr.reachable = nil
 	for _, e := range edges {
 		wr.CallGraph[e.Caller] = append(wr.CallGraph[e.Caller], e.Callee)
 	}
 }
 
-// IsWorkflowReachable determines if a function (in canonical form) is reachable from workflow code
-func (wr *WorkflowRegistry) IsWorkflowReachable(canonicalFuncName string) bool {
+// IsWorkflowReachable determines if a function is reachable from workflow code
+func (wr *WorkflowRegistry) IsWorkflowReachable(id FuncID) bool {
+	// Excluded functions are never analyzed
+	if wr.IsExcluded(id) {
+		return false
+	}
+
 	// Direct workflow function
-	if wr.WorkflowFuncs[canonicalFuncName] {
+	if wr.WorkflowFuncs[id] {
 		return true
 	}
 
+	if wr.reachable != nil {
+		return wr.reachable[id]
+	}
+
 	// Check if reachable from any workflow function via call graph
-	visited := make(map[string]bool)
-	return wr.isReachableFrom(canonicalFuncName, wr.WorkflowFuncs, visited)
+	visited := make(map[FuncID]bool)
+	return wr.isReachableFrom(id, wr.WorkflowFuncs, visited)
 }
 
-// isReachableFrom performs recursive reachability analysis
-func (wr *WorkflowRegistry) isReachableFrom(target string, sources map[string]bool, visited map[string]bool) bool {
-	if visited[target] {
-		return false // Avoid infinite loops
+// BuildReachabilityCache precomputes IsWorkflowReachable for every function,
+// so later calls are map lookups instead of a call graph search each. Call it
+// once the registry is complete, before sharing it between goroutines;
+// MarkWorkflow, AddEdges and ExcludeFunctions drop the cache again, but
+// writing to WorkflowFuncs or CallGraph directly does not.
+func (wr *WorkflowRegistry) BuildReachabilityCache() {
+	reach := make(map[FuncID]bool)
+	visited := make(map[FuncID]bool)
+	frontier := make([]FuncID, 0, len(wr.WorkflowFuncs))
+	for wf := range wr.WorkflowFuncs {
+		frontier = append(frontier, wf)
+	}
+	for len(frontier) > 0 {
+		fn := frontier[len(frontier)-1]
+		frontier = frontier[:len(frontier)-1]
+		if visited[fn] || wr.isOpaque(fn) {
+			continue
+		}
+		visited[fn] = true
+		for _, callee := range wr.CallGraph[fn] {
+			reach[callee] = true
+			if !visited[callee] {
+				frontier = append(frontier, callee)
+			}
+		}
 	}
-	visited[target] = true
+	wr.reachable = reach
+}
 
-	// Check if any source directly calls the target
-	for source := range sources {
-		for _, callee := range wr.CallGraph[source] {
-			if callee == target {
+// isReachableFrom searches the call graph breadth-first from sources for target.
+// visited tracks expanded callers, so cycles terminate.
+func (wr *WorkflowRegistry) isReachableFrom(target FuncID, sources map[FuncID]bool, visited map[FuncID]bool) bool {
+	frontier := sources
+	for len(frontier) > 0 {
+		nextLevel := make(map[FuncID]bool)
+		for source := range frontier {
+			if visited[source] || wr.isOpaque(source) {
+				continue
+			}
+			visited[source] = true
+			for _, callee := range wr.CallGraph[source] {
+				if callee == target {
+					return true
+				}
+				if !visited[callee] {
+					nextLevel[callee] = true
+				}
+			}
+		}
+		frontier = nextLevel
+	}
+	return false
+}
+
+// NewWorkflowRegistry creates a fresh registry instance.
+func NewWorkflowRegistry() *WorkflowRegistry {
+	return &WorkflowRegistry{
+		WorkflowFuncs: make(map[FuncID]bool),
+		ActivityFuncs: make(map[FuncID]bool),
+		CallGraph:     make(map[FuncID][]FuncID),
+		Registered:    make(map[FuncID]bool),
+		PackageVars:   make(map[string]map[string]bool),
+		VarDecls:      make(map[string]map[string]VarDecl),
+		ActivityNames: make(map[string]bool),
+	}
+}
+
+// HasCallers reports whether any function in the call graph calls id.
+func (wr *WorkflowRegistry) HasCallers(id FuncID) bool {
+	for _, callees := range wr.CallGraph {
+		for _, callee := range callees {
+			if callee == id {
 				return true
 			}
 		}
 	}
+	return false
+}
 
-	// Recursively check indirect calls
-	nextLevel := make(map[string]bool)
-	for source := range sources {
-		for _, callee := range wr.CallGraph[source] {
-			nextLevel[callee] = true
+// recordRegistration marks the function references among a workflow
+// registration call's arguments (workflow.Register(Fn),
+// w.RegisterWorkflow(pkg.Fn), ...) as registered.
+func (wr *WorkflowRegistry) recordRegistration(call *ast.CallExpr, pkgPath string, importMap map[string]string) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	switch sel.Sel.Name {
+	case "Register", "RegisterWithOptions", "RegisterWorkflow", "RegisterWorkflowWithOptions":
+	default:
+		return
+	}
+	for _, arg := range call.Args {
+		switch a := arg.(type) {
+		case *ast.Ident:
+			wr.Registered[NewFuncID(pkgPath, a.Name)] = true
+		case *ast.SelectorExpr:
+			if pkg, ok := a.X.(*ast.Ident); ok && importMap[pkg.Name] != "" {
+				wr.Registered[NewFuncID(importMap[pkg.Name], a.Sel.Name)] = true
+			}
 		}
 	}
+}
 
-	if len(nextLevel) > 0 {
-		return wr.isReachableFrom(target, nextLevel, visited)
+// ActivityNameRegistered reports whether name is one of the names activities
+// are registered under. ok is false when that can't be decided: no activity
+// registration was seen, or one registered an activity under a name that
+// can't be determined statically (a struct of activities, a non-literal name).
+func (wr *WorkflowRegistry) ActivityNameRegistered(name string) (registered, ok bool) {
+	if wr.opaqueActivity || len(wr.ActivityNames) == 0 {
+		return false, false
 	}
+	return wr.ActivityNames[name], true
+}
 
-	return false
+// recordActivityRegistration adds the names an activity registration call
+// (activity.Register(Fn), w.RegisterActivityWithOptions(Fn, opts), ...)
+// registers to ActivityNames. A function registered without an explicit name
+// is recorded under both its bare and its package-qualified name.
+func (wr *WorkflowRegistry) recordActivityRegistration(call *ast.CallExpr, pkgPath string, importMap map[string]string) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) == 0 {
+		return
+	}
+	switch sel.Sel.Name {
+	case "RegisterActivity", "RegisterActivityWithOptions":
+	case "Register", "RegisterWithOptions":
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return
+		}
+		if path := importMap[pkg.Name]; path != "activity" && !strings.HasSuffix(path, "/activity") {
+			return
+		}
+	default:
+		return
+	}
+
+	var fnPkg, fnName string
+	switch a := call.Args[0].(type) {
+	case *ast.Ident:
+		if a.Obj != nil && a.Obj.Kind != ast.Fun {
+			// a variable, e.g. a struct whose methods are the activities
+			wr.opaqueActivity = true
+			return
+		}
+		fnPkg, fnName = pkgPath, a.Name
+	case *ast.SelectorExpr:
+		pkg, ok := a.X.(*ast.Ident)
+		if !ok || importMap[pkg.Name] == "" {
+			wr.opaqueActivity = true
+			return
+		}
+		fnPkg, fnName = importMap[pkg.Name], a.Sel.Name
+	default:
+		wr.opaqueActivity = true
+		return
+	}
+
+	if len(call.Args) > 1 {
+		name, ok := registeredName(call.Args[1])
+		if !ok {
+			wr.opaqueActivity = true
+			return
+		}
+		if name != "" {
+			wr.ActivityNames[name] = true
+			return
+		}
+	}
+	wr.ActivityNames[fnName] = true
+	wr.ActivityNames[fnPkg+"."+fnName] = true
 }
 
-// NewWorkflowRegistry creates a fresh registry instance.
-func NewWorkflowRegistry() *WorkflowRegistry {
-	return &WorkflowRegistry{
-		WorkflowFuncs: make(map[string]bool),
-		ActivityFuncs: make(map[string]bool),
-		CallGraph:     make(map[string][]string),
+// registeredName returns the Name field of a RegisterOptions literal, or ""
+// when it sets none. ok is false when the name isn't a string literal.
+func registeredName(opts ast.Expr) (name string, ok bool) {
+	if u, isAddr := opts.(*ast.UnaryExpr); isAddr && u.Op == token.AND {
+		opts = u.X
+	}
+	lit, isLit := opts.(*ast.CompositeLit)
+	if !isLit {
+		return "", false
+	}
+	for _, elt := range lit.Elts {
+		kv, isKV := elt.(*ast.KeyValueExpr)
+		if !isKV {
+			continue
+		}
+		if key, isIdent := kv.Key.(*ast.Ident); !isIdent || key.Name != "Name" {
+			continue
+		}
+		val, isStr := kv.Value.(*ast.BasicLit)
+		if !isStr || val.Kind != token.STRING {
+			return "", false
+		}
+		s, err := strconv.Unquote(val.Value)
+		return s, err == nil
+	}
+	return "", true
+}
+
+// recordPackageVars adds the package-level variables declared in file to
+// PackageVars[pkgPath] and VarDecls[pkgPath]. Constants are not recorded.
+func (wr *WorkflowRegistry) recordPackageVars(file *ast.File, pkgPath string, importMap map[string]string) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if name.Name == "_" {
+					continue
+				}
+				if wr.PackageVars[pkgPath] == nil {
+					wr.PackageVars[pkgPath] = map[string]bool{}
+					wr.VarDecls[pkgPath] = map[string]VarDecl{}
+				}
+				wr.PackageVars[pkgPath][name.Name] = true
+				decl := VarDecl{Type: vs.Type, ImportMap: importMap}
+				if len(vs.Values) == len(vs.Names) {
+					decl.Value = vs.Values[i]
+				}
+				wr.VarDecls[pkgPath][name.Name] = decl
+			}
+		}
 	}
 }
 
 // ProcessFile analyzes a single file to classify functions and build call graph edges
 // This replaces the old Visit method with a more structured approach
 func (wr *WorkflowRegistry) ProcessFile(file *ast.File, pkgPath string, importMap map[string]string) {
+	wr.recordPackageVars(file, pkgPath, importMap)
+
 	// 1) Classify functions by signature (workflow.Context vs context.Context)
 	ast.Inspect(file, func(node ast.Node) bool {
 		if fn, ok := node.(*ast.FuncDecl); ok && fn.Name != nil {
@@ -92,11 +382,11 @@ func (wr *WorkflowRegistry) ProcessFile(file *ast.File, pkgPath string, importMa
 					// Expect SelectorExpr like: workflow.Context or context.Context
 					if sel, ok := param.Type.(*ast.SelectorExpr); ok {
 						if ident, ok := sel.X.(*ast.Ident); ok && sel.Sel.Name == "Context" {
-							switch ident.Name {
-							case "workflow":
-								wr.MarkWorkflow(pkgPath, fn.Name.Name)
-							case "context":
-								wr.MarkActivity(pkgPath, fn.Name.Name)
+							switch {
+							case ident.Name == "workflow" || wr.contextPkgs[importMap[ident.Name]]:
+								wr.MarkWorkflow(pkgPath, FuncDeclName(fn))
+							case ident.Name == "context":
+								wr.MarkActivity(pkgPath, FuncDeclName(fn))
 							}
 						}
 					}
@@ -106,6 +396,8 @@ func (wr *WorkflowRegistry) ProcessFile(file *ast.File, pkgPath string, importMa
 
 		// Classify by registration calls (workflow.Register / RegisterActivity)
 		if call, ok := node.(*ast.CallExpr); ok {
+			wr.recordRegistration(call, pkgPath, importMap)
+			wr.recordActivityRegistration(call, pkgPath, importMap)
 			if sel, ok := call.Fun.(*ast.SelectorExpr); ok {
 				if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "workflow" {
 					switch sel.Sel.Name {
@@ -188,21 +480,35 @@ func (wr *WorkflowRegistry) Visit(node ast.Node) ast.Visitor {
 
 // ReachableFromWorkflows returns a set of functions that are reachable
 // from any workflow function by following call graph edges (excludes activities).
-func (wr *WorkflowRegistry) ReachableFromWorkflows() map[string]bool {
-	reach := make(map[string]bool)
-	visited := make(map[string]bool)
+func (wr *WorkflowRegistry) ReachableFromWorkflows() map[FuncID]bool {
+	reach := make(map[FuncID]bool)
+	visited := make(map[FuncID]bool)
 	for wf := range wr.WorkflowFuncs {
 		wr.collectReachable(wf, reach, visited)
 	}
 	return reach
 }
 
-func (wr *WorkflowRegistry) collectReachable(fn string, reach, visited map[string]bool) {
+// ReachableFrom returns the roots and every function they reach through the
+// call graph, with the same activity and opaque cut-offs as workflows.
+func (wr *WorkflowRegistry) ReachableFrom(roots []FuncID) map[FuncID]bool {
+	reach := make(map[FuncID]bool)
+	visited := make(map[FuncID]bool)
+	for _, fn := range roots {
+		wr.collectReachable(fn, reach, visited)
+	}
+	return reach
+}
+
+func (wr *WorkflowRegistry) collectReachable(fn FuncID, reach, visited map[FuncID]bool) {
 	if visited[fn] {
 		return
 	}
 	visited[fn] = true
 	reach[fn] = true
+	if wr.isOpaque(fn) {
+		return
+	}
 
 	for _, callee := range wr.CallGraph[fn] {
 		// Skip activities in reachability.
@@ -213,20 +519,19 @@ func (wr *WorkflowRegistry) collectReachable(fn string, reach, visited map[strin
 	}
 }
 
-// CallPathTo returns one simple call path (as a slice of function names)
-// from any workflow function to the target function, if one exists.
-// Used to attach a "call stack" for explanation.
-func (wr *WorkflowRegistry) CallPathTo(target string) []string {
+// CallPathTo returns one simple call path from any workflow function to the
+// target function, if one exists. Used to attach a "call stack" for explanation.
+func (wr *WorkflowRegistry) CallPathTo(target FuncID) []FuncID {
 	// BFS from all workflow funcs
 	type qitem struct {
-		name string
-		path []string
+		name FuncID
+		path []FuncID
 	}
-	seen := make(map[string]bool)
+	seen := make(map[FuncID]bool)
 	var q []qitem
 
 	for wf := range wr.WorkflowFuncs {
-		q = append(q, qitem{name: wf, path: []string{wf}})
+		q = append(q, qitem{name: wf, path: []FuncID{wf}})
 		seen[wf] = true
 	}
 
@@ -237,6 +542,9 @@ func (wr *WorkflowRegistry) CallPathTo(target string) []string {
 		if cur.name == target {
 			return cur.path
 		}
+		if wr.isOpaque(cur.name) {
+			continue
+		}
 
 		for _, callee := range wr.CallGraph[cur.name] {
 			// Skip activities in call path
@@ -245,7 +553,7 @@ func (wr *WorkflowRegistry) CallPathTo(target string) []string {
 			}
 			if !seen[callee] {
 				seen[callee] = true
-				next := append(append([]string{}, cur.path...), callee)
+				next := append(append([]FuncID{}, cur.path...), callee)
 				q = append(q, qitem{name: callee, path: next})
 			}
 		}
@@ -254,9 +562,9 @@ func (wr *WorkflowRegistry) CallPathTo(target string) []string {
 }
 
 // GetCallStack provides debugging information for call paths from workflow to target
-func (wr *WorkflowRegistry) GetCallStack(from, to string) []string {
-	visited := make(map[string]bool)
-	path := []string{}
+func (wr *WorkflowRegistry) GetCallStack(from, to FuncID) []FuncID {
+	visited := make(map[FuncID]bool)
+	path := []FuncID{}
 	if wr.findPath(from, to, visited, &path) {
 		return path
 	}
@@ -264,7 +572,7 @@ func (wr *WorkflowRegistry) GetCallStack(from, to string) []string {
 }
 
 // findPath performs recursive path finding for call stack construction
-func (wr *WorkflowRegistry) findPath(from, to string, visited map[string]bool, path *[]string) bool {
+func (wr *WorkflowRegistry) findPath(from, to FuncID, visited map[FuncID]bool, path *[]FuncID) bool {
 	if visited[from] {
 		return false
 	}
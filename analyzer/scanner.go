@@ -1,12 +1,19 @@
 package analyzer
 
 import (
+	"errors"
+	"fmt"
 	"go/ast"
+	"go/build"
 	"go/parser"
 	"go/token"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
 	"github.com/afony10/cadence-workflow-linter/analyzer/modutils"
@@ -17,11 +24,12 @@ import (
 type PackageResolver struct {
 	moduleInfo *modutils.ModuleInfo
 	baseDir    string
+	modules    map[string]*modutils.ModuleInfo // directory -> owning module (nil if none)
 }
 
 // NewPackageResolver creates a resolver with go.mod parsing and fallback heuristics
 func NewPackageResolver(baseDir string) *PackageResolver {
-	resolver := &PackageResolver{baseDir: baseDir}
+	resolver := &PackageResolver{baseDir: baseDir, modules: map[string]*modutils.ModuleInfo{}}
 
 	// Try to find and parse go.mod (Solution 1)
 	if goModPath, err := modutils.FindGoMod(baseDir); err == nil {
@@ -59,8 +67,8 @@ func (pr *PackageResolver) computePackagePath(filePath string, node *ast.File) s
 	}
 
 	// Use go.mod info if available (Solution 1)
-	if pr.moduleInfo != nil {
-		modulePath := pr.moduleInfo.ModulePath
+	if moduleInfo := pr.moduleFor(filePath); moduleInfo != nil {
+		modulePath := moduleInfo.ModulePath
 
 		// For main package, return the module path
 		if pkgName == "main" {
@@ -68,7 +76,7 @@ func (pr *PackageResolver) computePackagePath(filePath string, node *ast.File) s
 		}
 
 		// For subpackages, build the full path
-		rel, err := filepath.Rel(pr.moduleInfo.RootDir, filepath.Dir(filePath))
+		rel, err := filepath.Rel(moduleInfo.RootDir, absDir(filePath))
 		if err == nil && rel != "." {
 			subPath := strings.ReplaceAll(rel, string(filepath.Separator), "/")
 			return modulePath + "/" + subPath
@@ -92,12 +100,41 @@ func (pr *PackageResolver) computePackagePath(filePath string, node *ast.File) s
 	return pkgName
 }
 
+// moduleFor returns the module owning filePath, i.e. the nearest go.mod above it.
+// Resolving per file keeps canonical names unique when a workspace (go.work)
+// holds several modules with identically named packages.
+func (pr *PackageResolver) moduleFor(filePath string) *modutils.ModuleInfo {
+	dir := absDir(filePath)
+	if mi, ok := pr.modules[dir]; ok {
+		return mi
+	}
+	mi := pr.moduleInfo
+	if goModPath, err := modutils.FindGoMod(dir); err == nil {
+		if pr.moduleInfo == nil || filepath.Dir(goModPath) != pr.moduleInfo.RootDir {
+			if parsed, err := modutils.ParseGoMod(goModPath); err == nil {
+				mi = parsed
+			}
+		}
+	}
+	pr.modules[dir] = mi
+	return mi
+}
+
+func absDir(filePath string) string {
+	dir := filepath.Dir(filePath)
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return dir
+}
+
 type parsedFile struct {
 	filename  string
 	fset      *token.FileSet
 	node      *ast.File
 	importMap map[string]string
 	pkgPath   string // canonical package path
+	ignores   suppressions
 }
 
 // Build an alias->import map for the file (e.g., r -> math/rand)
@@ -120,10 +157,222 @@ func buildImportMap(f *ast.File) map[string]string {
 	return m
 }
 
-// First pass: parse files and build the global registry (workflows, activities, call graph)
-func parseAllAndBuildRegistry(target string) ([]parsedFile, *registry.WorkflowRegistry, *modutils.ModuleInfo, error) {
+// Options tunes a scan. The zero value analyzes every function.
+type Options struct {
+	// ExcludeFunctions are canonical-name globs (e.g. "example.com/app/gen.*")
+	// of functions whose bodies detectors skip.
+	ExcludeFunctions []string
+	// OpaqueExcluded stops reachability at excluded functions, so their
+	// callees aren't treated as workflow code through them.
+	OpaqueExcluded bool
+	// WorkflowContextPackages are import paths whose Context type marks a
+	// function as workflow code, e.g. in-house wrappers of workflow.Context.
+	WorkflowContextPackages []string
+	// Func, if set, restricts reported issues to the function with this
+	// canonical or package-relative name. The whole registry is still built,
+	// so reachability is unaffected.
+	Func string
+	// FuncTransitive widens Func to the functions it reaches.
+	FuncTransitive bool
+	// MessageTemplates replaces the messages of built-in detector rules,
+	// keyed by rule name; see detectors.RenderMessage.
+	MessageTemplates map[string]string
+	// DedupePositions keeps a single issue per file position when several
+	// detectors or rules flag the same code: the one whose rule comes first
+	// in PreferredRules, else the most severe.
+	DedupePositions bool
+	PreferredRules  []string
+	// Exclude are glob patterns, relative to each directory target, of files
+	// the walk skips; see matchGlob. Files named as targets are always scanned.
+	Exclude []string
+	// IncludeVendor makes directory walks enter vendor directories, which
+	// are skipped by default.
+	IncludeVendor bool
+	// FollowSymlinks makes directory walks enter symlinked directories.
+	// Each directory is walked once, so symlink loops terminate.
+	FollowSymlinks bool
+	// GOOS and GOARCH are the platform directory walks select files for:
+	// files whose //go:build line or _GOOS/_GOARCH name suffix excludes it
+	// are skipped, as the go command would. Empty means the host's.
+	GOOS   string
+	GOARCH string
+	// Workers is how many files the detector pass analyzes concurrently;
+	// zero or less means runtime.GOMAXPROCS(0). Output order never depends on it.
+	Workers int
+}
+
+// First pass: parse the files of all targets and build one global registry
+// (workflows, activities, call graph), so calls across targets are followed.
+// With parseErrs, files that fail to parse are appended to it and left out
+// instead of failing the scan.
+func parseAllAndBuildRegistry(targets []string, opts Options, parseErrs *[]*ParseError) ([]parsedFile, *registry.WorkflowRegistry, *modutils.ModuleInfo, error) {
 	var files []parsedFile
-	wr := registry.NewWorkflowRegistry()
+	var moduleInfo *modutils.ModuleInfo
+	seen := map[string]bool{}
+	for _, target := range targets {
+		parsed, mi, err := parseTarget(target, seen, opts, parseErrs)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		files = append(files, parsed...)
+		if moduleInfo == nil {
+			moduleInfo = mi
+		}
+	}
+
+	wr, err := buildRegistry(files, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return files, wr, moduleInfo, nil
+}
+
+// ListFiles returns the files a scan of targets with opts would analyze, in
+// scan order, without parsing them.
+func ListFiles(targets []string, opts Options) ([]string, error) {
+	var all []string
+	seen := map[string]bool{}
+	for _, target := range targets {
+		files, err := targetFiles(target, seen, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, files...)
+	}
+	return all, nil
+}
+
+// targetFiles returns the Go files of a file or directory target. Files already
+// in seen (from overlapping targets, or reached again through a symlink) are
+// skipped. In directories, files matching opts.Exclude, files excluded by
+// build constraints for opts.GOOS/opts.GOARCH and vendor trees (unless
+// opts.IncludeVendor) are skipped too; symlinked directories are only entered
+// with opts.FollowSymlinks.
+func targetFiles(target string, seen map[string]bool, opts Options) ([]string, error) {
+	var files []string
+	add := func(path string) {
+		key, err := filepath.EvalSymlinks(path)
+		if err == nil {
+			key, err = filepath.Abs(key)
+		}
+		if err == nil {
+			if seen[key] {
+				return
+			}
+			seen[key] = true
+		}
+		files = append(files, path)
+	}
+
+	info, err := os.Stat(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %w", ErrTargetNotFound, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		add(target)
+		return files, nil
+	}
+	ctxt := buildContext(opts)
+	skipDir := func(path string) bool {
+		return !opts.IncludeVendor && path != target && filepath.Base(path) == "vendor"
+	}
+	visit := func(path string) {
+		rel, err := filepath.Rel(target, path)
+		if err != nil {
+			rel = path
+		}
+		rel = filepath.ToSlash(rel)
+		for _, pattern := range opts.Exclude {
+			if matchGlob(pattern, rel) {
+				return
+			}
+		}
+		// A file whose constraints can't be read is kept, so parsing reports it.
+		if ok, err := ctxt.MatchFile(filepath.Dir(path), filepath.Base(path)); err == nil && !ok {
+			return
+		}
+		add(path)
+	}
+	if opts.FollowSymlinks {
+		err = walkFollowingSymlinks(target, map[string]bool{}, skipDir, visit)
+		return files, err
+	}
+	err = filepath.Walk(target, func(path string, fi os.FileInfo, _ error) error {
+		if fi != nil && fi.IsDir() && skipDir(path) {
+			return filepath.SkipDir
+		}
+		if fi != nil && !fi.IsDir() && filepath.Ext(path) == ".go" {
+			visit(path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// buildContext returns the go/build context for opts.GOOS and opts.GOARCH,
+// defaulting to the host's.
+func buildContext(opts Options) *build.Context {
+	ctxt := build.Default
+	if opts.GOOS != "" {
+		ctxt.GOOS = opts.GOOS
+	}
+	if opts.GOARCH != "" {
+		ctxt.GOARCH = opts.GOARCH
+	}
+	return &ctxt
+}
+
+// walkFollowingSymlinks calls visit for every .go file under dir in lexical
+// order, entering symlinked directories as if they were regular ones. Files
+// keep the path they were reached through, so their package path follows the
+// link's location. visited holds the resolved directories already walked,
+// which stops symlink loops. Dangling links and directories for which skipDir
+// returns true are skipped.
+func walkFollowingSymlinks(dir string, visited map[string]bool, skipDir func(path string) bool, visit func(path string)) error {
+	real, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return err
+	}
+	if visited[real] {
+		return nil
+	}
+	visited[real] = true
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if info.IsDir() {
+			if skipDir(path) {
+				continue
+			}
+			if err := walkFollowingSymlinks(path, visited, skipDir, visit); err != nil {
+				return err
+			}
+		} else if filepath.Ext(path) == ".go" {
+			visit(path)
+		}
+	}
+	return nil
+}
+
+// parseTarget parses the Go files of a file or directory target. Files already
+// in seen (from overlapping targets) are skipped. A file that fails to parse
+// fails the target, unless parseErrs is set to collect it instead.
+func parseTarget(target string, seen map[string]bool, opts Options, parseErrs *[]*ParseError) ([]parsedFile, *modutils.ModuleInfo, error) {
+	paths, err := targetFiles(target, seen, opts)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Determine base directory for package path computation
 	baseDir := target
@@ -134,15 +383,20 @@ func parseAllAndBuildRegistry(target string) ([]parsedFile, *registry.WorkflowRe
 	// Create package resolver with hybrid approach
 	resolver := NewPackageResolver(baseDir)
 
-	addFile := func(path string) error {
+	files := make([]parsedFile, 0, len(paths))
+	for _, path := range paths {
 		src, err := os.ReadFile(path)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 		fset := token.NewFileSet()
-		node, err := parser.ParseFile(fset, path, src, parser.AllErrors)
+		node, err := parser.ParseFile(fset, path, src, parser.AllErrors|parser.ParseComments)
 		if err != nil {
-			return err
+			if parseErrs == nil {
+				return nil, nil, &ParseError{File: path, Err: err}
+			}
+			*parseErrs = append(*parseErrs, &ParseError{File: path, Err: err})
+			continue
 		}
 
 		importMap := buildImportMap(node)
@@ -156,78 +410,273 @@ func parseAllAndBuildRegistry(target string) ([]parsedFile, *registry.WorkflowRe
 			node:      node,
 			importMap: importMap,
 			pkgPath:   pkgPath,
+			ignores:   collectSuppressions(fset, node),
 		})
+	}
+	return files, resolver.moduleInfo, nil
+}
 
-		// Use the new ProcessFile method instead of ast.Walk
-		wr.ProcessFile(node, pkgPath, importMap)
-
-		return nil
+// buildRegistry records workflows, activities and the call graph of already-parsed files.
+func buildRegistry(files []parsedFile, opts Options) (*registry.WorkflowRegistry, error) {
+	wr := registry.NewWorkflowRegistry()
+	if err := wr.ExcludeFunctions(opts.ExcludeFunctions, opts.OpaqueExcluded); err != nil {
+		return nil, err
 	}
+	wr.SetWorkflowContextPackages(opts.WorkflowContextPackages)
+	for _, pf := range files {
+		wr.ProcessFile(pf.node, pf.pkgPath, pf.importMap)
+	}
+	wr.BuildReachabilityCache()
+	return wr, nil
+}
 
-	info, err := os.Stat(target)
-	if err != nil {
-		return nil, nil, nil, err
+// Second pass: run detectors on each file with the global registry, spread
+// over opts.Workers goroutines. The registry is read-only by now, and every
+// file gets fresh detectors from factory, so files are independent.
+func runDetectors(files []parsedFile, wr *registry.WorkflowRegistry, moduleInfo *modutils.ModuleInfo, factory func(*modutils.ModuleInfo) []ast.Visitor, opts Options) ([]detectors.Issue, error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
 	}
-	if info.IsDir() {
-		err = filepath.Walk(target, func(path string, fi os.FileInfo, _ error) error {
-			if fi == nil || fi.IsDir() || filepath.Ext(path) != ".go" {
-				return nil
+
+	inScope := funcScope(wr, opts)
+	perFile := make([][]detectors.Issue, len(files))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				issues := dropSuppressed(detectFile(files[i], wr, moduleInfo, factory), files[i].ignores)
+				issues = keepIssues(dropActivityLiterals(issues, files[i]), files[i].pkgPath, inScope)
+				perFile[i] = applyTemplates(issues, files[i].pkgPath, opts.MessageTemplates)
 			}
-			return addFile(path)
-		})
-	} else {
-		err = addFile(target)
+		}()
 	}
-	if err != nil {
-		return nil, nil, nil, err
+	for i := range files {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	var all []detectors.Issue
+	for _, issues := range perFile {
+		all = append(all, issues...)
 	}
 
-	return files, wr, resolver.moduleInfo, nil
+	// Since detectors now handle workflow reachability checking internally,
+	// we only need to attach rule metadata
+	for i := range all {
+		all[i].Category = detectors.CategoryOf(all[i].Rule)
+	}
+	sortIssues(all)
+	if opts.DedupePositions {
+		all = dedupePositions(all, opts.PreferredRules)
+	}
+	return all, nil
 }
 
-// Second pass: run detectors on each file with global registry, then filter/enrich issues.
-func runDetectors(files []parsedFile, wr *registry.WorkflowRegistry, moduleInfo *modutils.ModuleInfo, factory func(*modutils.ModuleInfo) []ast.Visitor) ([]detectors.Issue, error) {
-	var all []detectors.Issue
+// detectFile runs a fresh set of detectors over one file.
+func detectFile(pf parsedFile, wr *registry.WorkflowRegistry, moduleInfo *modutils.ModuleInfo, factory func(*modutils.ModuleInfo) []ast.Visitor) []detectors.Issue {
+	var issues []detectors.Issue
+	visitors := factory(moduleInfo)
+	ctx := detectors.FileContext{File: pf.filename, Fset: pf.fset, ImportMap: pf.importMap}
+	for _, v := range visitors {
+		if wa, ok := v.(detectors.WorkflowAware); ok {
+			wa.SetWorkflowRegistry(wr)
+		}
+		if fca, ok := v.(detectors.FileContextAware); ok {
+			fca.SetFileContext(ctx)
+		}
+		if pa, ok := v.(detectors.PackageAware); ok {
+			pa.SetPackagePath(pf.pkgPath)
+		}
+		ast.Walk(v, pf.node)
+		if ip, ok := v.(detectors.IssueProvider); ok {
+			issues = append(issues, ip.Issues()...)
+		}
+	}
+	return issues
+}
 
-	// Run detectors over all files, collect issues
-	for _, pf := range files {
-		visitors := factory(moduleInfo)
-		ctx := detectors.FileContext{File: pf.filename, Fset: pf.fset, ImportMap: pf.importMap}
-		for _, v := range visitors {
-			if wa, ok := v.(detectors.WorkflowAware); ok {
-				wa.SetWorkflowRegistry(wr)
-			}
-			if fca, ok := v.(detectors.FileContextAware); ok {
-				fca.SetFileContext(ctx)
-			}
-			if pa, ok := v.(detectors.PackageAware); ok {
-				pa.SetPackagePath(pf.pkgPath)
-			}
-			ast.Walk(v, pf.node)
-			if ip, ok := v.(detectors.IssueProvider); ok {
-				all = append(all, ip.Issues()...)
+// dropActivityLiterals drops issues inside function literals that are
+// scheduled as activities (see registry.ActivityLiterals). Detectors attribute code in
+// literals to the enclosing function, which is right for closures run by the
+// workflow but not for local activities it schedules.
+func dropActivityLiterals(issues []detectors.Issue, pf parsedFile) []detectors.Issue {
+	type span struct{ start, end token.Position }
+	var spans []span
+	for lit := range registry.ActivityLiterals(pf.node, pf.importMap) {
+		spans = append(spans, span{pf.fset.Position(lit.Pos()), pf.fset.Position(lit.End())})
+	}
+	if len(spans) == 0 {
+		return issues
+	}
+	before := func(a, b token.Position) bool {
+		return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+	}
+	var kept []detectors.Issue
+	for _, is := range issues {
+		at := token.Position{Line: is.Line, Column: is.Column}
+		inside := false
+		for _, s := range spans {
+			if !before(at, s.start) && before(at, s.end) {
+				inside = true
+				break
 			}
 		}
+		if !inside {
+			kept = append(kept, is)
+		}
 	}
+	return kept
+}
 
-	// Since detectors now handle workflow reachability checking internally,
-	// we can return all issues directly
-	return all, nil
+// funcScope returns which functions' issues to report under opts.Func, or
+// nil to report all.
+func funcScope(wr *registry.WorkflowRegistry, opts Options) func(registry.FuncID) bool {
+	if opts.Func == "" {
+		return nil
+	}
+	if !opts.FuncTransitive {
+		return func(id registry.FuncID) bool { return id.Matches(opts.Func) }
+	}
+	var roots []registry.FuncID
+	for caller := range wr.CallGraph {
+		if caller.Matches(opts.Func) {
+			roots = append(roots, caller)
+		}
+	}
+	reach := wr.ReachableFrom(roots)
+	return func(id registry.FuncID) bool { return id.Matches(opts.Func) || reach[id] }
+}
+
+// keepIssues drops issues of a file in package pkgPath whose function is out of scope.
+func keepIssues(issues []detectors.Issue, pkgPath string, inScope func(registry.FuncID) bool) []detectors.Issue {
+	if inScope == nil {
+		return issues
+	}
+	var kept []detectors.Issue
+	for _, is := range issues {
+		if is.Func != "" && inScope(registry.NewFuncID(pkgPath, is.Func)) {
+			kept = append(kept, is)
+		}
+	}
+	return kept
+}
+
+// applyTemplates renders the message template of each issue's rule, if any.
+func applyTemplates(issues []detectors.Issue, pkgPath string, templates map[string]string) []detectors.Issue {
+	if len(templates) == 0 {
+		return issues
+	}
+	for i, is := range issues {
+		tmpl, ok := templates[is.Rule]
+		if !ok {
+			continue
+		}
+		issues[i].Message = detectors.RenderMessage(tmpl, detectors.MessageVars{
+			Func:    is.Func,
+			Pkg:     pkgPath,
+			File:    is.File,
+			Line:    is.Line,
+			Message: is.Message,
+		})
+	}
+	return issues
+}
+
+// sortIssues orders issues by file, line, column, rule and message, so
+// reports are byte-identical across runs whatever the worker count.
+func sortIssues(issues []detectors.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Message < b.Message
+	})
 }
 
 // Public API: ScanFile or ScanDirectory using two-pass analysis
 func ScanFile(path string, factory func(*modutils.ModuleInfo) []ast.Visitor) ([]detectors.Issue, error) {
-	files, wr, moduleInfo, err := parseAllAndBuildRegistry(path)
+	return ScanWithOptions(path, factory, Options{})
+}
+
+func ScanDirectory(root string, factory func(*modutils.ModuleInfo) []ast.Visitor) ([]detectors.Issue, error) {
+	return ScanWithOptions(root, factory, Options{})
+}
+
+// ScanWithOptions scans a file or directory with the given options.
+func ScanWithOptions(target string, factory func(*modutils.ModuleInfo) []ast.Visitor, opts Options) ([]detectors.Issue, error) {
+	return ScanTargets([]string{target}, factory, opts)
+}
+
+// ScanTargets scans several files and directories with one shared registry,
+// so workflows in one target make helpers in another reachable. A file that
+// fails to parse doesn't stop the scan: it is left out of the analysis and
+// reported as a ParseError issue, and the other files are analyzed as usual.
+func ScanTargets(targets []string, factory func(*modutils.ModuleInfo) []ast.Visitor, opts Options) ([]detectors.Issue, error) {
+	issues, parseErrs, err := ScanTargetsPartial(targets, factory, opts)
 	if err != nil {
 		return nil, err
 	}
-	return runDetectors(files, wr, moduleInfo, factory)
+	if len(parseErrs) == 0 {
+		return issues, nil
+	}
+	issues = append(issues, parseErrorIssues(parseErrs)...)
+	sortIssues(issues)
+	return issues, nil
 }
 
-func ScanDirectory(root string, factory func(*modutils.ModuleInfo) []ast.Visitor) ([]detectors.Issue, error) {
-	files, wr, moduleInfo, err := parseAllAndBuildRegistry(root)
+// ScanTargetsPartial is ScanTargets for callers that handle parse failures
+// themselves: files that fail to parse are returned, in scan order, alongside
+// the issues of the rest instead of as ParseError issues. The error is
+// reserved for failures that stop the scan, such as a missing target.
+func ScanTargetsPartial(targets []string, factory func(*modutils.ModuleInfo) []ast.Visitor, opts Options) ([]detectors.Issue, []*ParseError, error) {
+	var parseErrs []*ParseError
+	files, wr, moduleInfo, err := parseAllAndBuildRegistry(targets, opts, &parseErrs)
+	if err != nil {
+		return nil, nil, err
+	}
+	issues, err := runDetectors(files, wr, moduleInfo, factory, opts)
+	return issues, parseErrs, err
+}
+
+// ScanParsed runs the two-pass analysis on files the caller has already parsed
+// into fset, skipping the filesystem walk. pkgPaths gives each file's canonical
+// package path; files missing from it fall back to their package name.
+// Ignore directives are only honored in files parsed with parser.ParseComments.
+// moduleInfo, if known, lets detectors tell local packages from external ones.
+func ScanParsed(files []*ast.File, fset *token.FileSet, pkgPaths map[*ast.File]string, moduleInfo *modutils.ModuleInfo, factory func(*modutils.ModuleInfo) []ast.Visitor, opts Options) ([]detectors.Issue, error) {
+	parsed := make([]parsedFile, 0, len(files))
+	for _, node := range files {
+		pkgPath, ok := pkgPaths[node]
+		if !ok {
+			pkgPath = node.Name.Name
+		}
+		parsed = append(parsed, parsedFile{
+			filename:  fset.Position(node.Pos()).Filename,
+			fset:      fset,
+			node:      node,
+			importMap: buildImportMap(node),
+			pkgPath:   pkgPath,
+			ignores:   collectSuppressions(fset, node),
+		})
+	}
+	wr, err := buildRegistry(parsed, opts)
 	if err != nil {
 		return nil, err
 	}
-	return runDetectors(files, wr, moduleInfo, factory)
+	return runDetectors(parsed, wr, moduleInfo, factory, opts)
 }
@@ -0,0 +1,132 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+	"github.com/afony10/cadence-workflow-linter/analyzer/modutils"
+	"github.com/afony10/cadence-workflow-linter/config"
+)
+
+func TestListFiles(t *testing.T) {
+	root := t.TempDir()
+	a := filepath.Join(root, "a.go")
+	b := filepath.Join(root, "sub", "b.go")
+	writeFile(t, a, "package a\n")
+	writeFile(t, b, "package sub\n")
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/a\n")
+	writeFile(t, filepath.Join(root, "notes.txt"), "not Go\n")
+	// Unparsable files are still listed: listing doesn't parse.
+	broken := filepath.Join(root, "sub", "broken.go")
+	writeFile(t, broken, "package sub\n\nfunc Broken( {\n")
+
+	// a.go is named twice through overlapping targets but listed once.
+	got, err := ListFiles([]string{a, root}, Options{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	want := []string{a, b, broken}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestListFilesFollowSymlinks(t *testing.T) {
+	root := t.TempDir()
+	app := filepath.Join(root, "app")
+	a := filepath.Join(app, "a.go")
+	writeFile(t, a, "package app\n")
+	writeFile(t, filepath.Join(app, "go.mod"), "module example.com/app\n")
+	writeFile(t, filepath.Join(root, "shared", "b.go"), "package shared\n")
+	if err := os.Symlink(filepath.Join("..", "shared"), filepath.Join(app, "vendored")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+	// A loop back to root must not make the walk recurse forever.
+	if err := os.Symlink("..", filepath.Join(root, "shared", "loop")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+
+	got, err := ListFiles([]string{app}, Options{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if want := []string{a}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("without following: got %v, want %v", got, want)
+	}
+
+	b := filepath.Join(app, "vendored", "b.go")
+	got, err = ListFiles([]string{app}, Options{FollowSymlinks: true})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if want := []string{a, b}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("following: got %v, want %v", got, want)
+	}
+
+	// The linked file's package path follows the link, not its real location.
+	files, _, err := parseTarget(app, map[string]bool{}, Options{FollowSymlinks: true}, nil)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	pkgs := map[string]string{}
+	for _, pf := range files {
+		pkgs[pf.filename] = pf.pkgPath
+	}
+	if got := pkgs[b]; got != "example.com/app/vendored" {
+		t.Fatalf("package path of %s = %q, want example.com/app/vendored", b, got)
+	}
+}
+
+func TestScanExclude(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/app\n")
+	src := `package %s
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func ClockWorkflow(ctx workflow.Context) error {
+	_ = time.Now()
+	return nil
+}
+`
+	writeFile(t, filepath.Join(root, "app.go"), fmt.Sprintf(src, "app"))
+	writeFile(t, filepath.Join(root, "svc", "mocks", "mock.go"), fmt.Sprintf(src, "mocks"))
+	writeFile(t, filepath.Join(root, "api_gen.go"), fmt.Sprintf(src, "app"))
+	writeFile(t, filepath.Join(root, "vendor", "dep", "dep.go"), fmt.Sprintf(src, "dep"))
+
+	factory := func(mi *modutils.ModuleInfo) []ast.Visitor {
+		return []ast.Visitor{detectors.NewFuncCallDetector([]config.FunctionRule{{Rule: "TimeUsage", Package: "time", Functions: []string{"Now"}, Severity: "error"}}, nil, nil, mi)}
+	}
+	files := func(opts Options) []string {
+		t.Helper()
+		issues, err := ScanWithOptions(root, factory, opts)
+		if err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		var got []string
+		for _, is := range issues {
+			rel, _ := filepath.Rel(root, is.File)
+			got = append(got, filepath.ToSlash(rel))
+		}
+		return got
+	}
+
+	if got, want := files(Options{}), []string{"api_gen.go", "app.go", "svc/mocks/mock.go"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("default: got %v, want %v (vendor skipped)", got, want)
+	}
+	if got, want := files(Options{Exclude: []string{"**/mocks/**", "*_gen.go"}}), []string{"app.go"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("excluded: got %v, want %v", got, want)
+	}
+	if got := files(Options{IncludeVendor: true}); len(got) != 4 || got[3] != "vendor/dep/dep.go" {
+		t.Errorf("include vendor: got %v", got)
+	}
+}
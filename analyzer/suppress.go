@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+// ignoreDirective is the line comment that suppresses issues on its own line
+// and on the line below it. It may name the rules to suppress, comma
+// separated (//workflow-lint:ignore TimeUsage,Randomness); without names it
+// suppresses every rule. Anything after the rule list is a free-form reason.
+const ignoreDirective = "workflow-lint:ignore"
+
+// suppressions maps the lines of node covered by an ignore directive to the
+// rules suppressed there; an empty rule name stands for all rules.
+type suppressions map[int][]string
+
+// collectSuppressions finds the ignore directives among node's comments. The
+// file must have been parsed with parser.ParseComments for any to be found.
+func collectSuppressions(fset *token.FileSet, node *ast.File) suppressions {
+	var sup suppressions
+	for _, group := range node.Comments {
+		for _, c := range group.List {
+			text, ok := strings.CutPrefix(c.Text, "//")
+			if !ok {
+				continue
+			}
+			rest, ok := strings.CutPrefix(strings.TrimSpace(text), ignoreDirective)
+			if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+				continue
+			}
+			var rules []string
+			if fields := strings.Fields(rest); len(fields) > 0 {
+				rules = strings.Split(fields[0], ",")
+			}
+			if sup == nil {
+				sup = suppressions{}
+			}
+			line := fset.Position(c.Slash).Line
+			for _, l := range []int{line, line + 1} {
+				if rules == nil {
+					sup[l] = append(sup[l], "")
+				} else {
+					sup[l] = append(sup[l], rules...)
+				}
+			}
+		}
+	}
+	return sup
+}
+
+// covers reports whether an issue of rule on line is suppressed.
+func (s suppressions) covers(line int, rule string) bool {
+	for _, r := range s[line] {
+		if r == "" || r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// dropSuppressed drops the issues of a file that an ignore directive covers.
+func dropSuppressed(issues []detectors.Issue, sup suppressions) []detectors.Issue {
+	if len(sup) == 0 {
+		return issues
+	}
+	var kept []detectors.Issue
+	for _, is := range issues {
+		if !sup.covers(is.Line, is.Rule) {
+			kept = append(kept, is)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,19 @@
+package analyzer
+
+import "runtime/debug"
+
+// Version is the linter release version. Release builds override it with
+// -ldflags "-X github.com/afony10/cadence-workflow-linter/analyzer.Version=vX.Y.Z".
+var Version = "dev"
+
+// ToolVersion returns the effective tool version, preferring an explicit
+// Version and falling back to the module version recorded in the build info.
+func ToolVersion() string {
+	if Version != "dev" {
+		return Version
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		return bi.Main.Version
+	}
+	return Version
+}
@@ -0,0 +1,15 @@
+package analyzer
+
+import "testing"
+
+func TestToolVersion(t *testing.T) {
+	defer func(v string) { Version = v }(Version)
+	Version = "v1.2.3"
+	if got := ToolVersion(); got != "v1.2.3" {
+		t.Errorf("expected the -ldflags version, got %q", got)
+	}
+	Version = "dev"
+	if got := ToolVersion(); got == "" {
+		t.Error("expected a fallback version")
+	}
+}
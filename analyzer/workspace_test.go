@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/registry"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestWorkspaceModulesHaveDistinctCanonicalNames(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.work"), "go 1.21\n\nuse (\n\t./moda\n\t./modb\n)\n")
+
+	for _, mod := range []string{"moda", "modb"} {
+		modPath := "example.com/" + mod
+		writeFile(t, filepath.Join(root, mod, "go.mod"), "module "+modPath+"\n\ngo 1.21\n")
+		writeFile(t, filepath.Join(root, mod, "pkgutil", "helper.go"), `package pkgutil
+
+import "time"
+
+func Helper() time.Time { return time.Now() }
+`)
+		writeFile(t, filepath.Join(root, mod, "app", "workflow.go"), `package app
+
+import (
+	"go.uber.org/cadence/workflow"
+	"`+modPath+`/pkgutil"
+)
+
+func Workflow(ctx workflow.Context) error {
+	_ = pkgutil.Helper()
+	return nil
+}
+`)
+	}
+
+	files, wr, _, err := parseAllAndBuildRegistry([]string{root}, Options{}, nil)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	pkgPaths := map[string]bool{}
+	for _, pf := range files {
+		pkgPaths[pf.pkgPath] = true
+	}
+	for _, want := range []string{"example.com/moda/pkgutil", "example.com/modb/pkgutil", "example.com/moda/app", "example.com/modb/app"} {
+		if !pkgPaths[want] {
+			t.Errorf("expected package path %s, got %v", want, pkgPaths)
+		}
+	}
+
+	for _, mod := range []string{"moda", "modb"} {
+		wf := registry.FuncID{Pkg: "example.com/" + mod + "/app", Name: "Workflow"}
+		helper := registry.FuncID{Pkg: "example.com/" + mod + "/pkgutil", Name: "Helper"}
+		if !wr.WorkflowFuncs[wf] {
+			t.Errorf("expected %s to be a workflow", wf)
+		}
+		if !wr.IsWorkflowReachable(helper) {
+			t.Errorf("expected %s to be reachable from a workflow", helper)
+		}
+		edges := wr.CallGraph[wf]
+		if len(edges) != 1 || edges[0] != helper {
+			t.Errorf("expected %s -> %s, got %v", wf, helper, edges)
+		}
+	}
+}
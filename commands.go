@@ -0,0 +1,150 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+	"github.com/afony10/cadence-workflow-linter/config"
+	"github.com/afony10/cadence-workflow-linter/output"
+)
+
+// subcommand splits args into a subcommand and its arguments. Anything that
+// isn't a known subcommand is passed to lint, so the flag-only invocation of
+// earlier releases keeps working.
+func subcommand(args []string) (string, []string) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "lint", "rules", "merge", "version":
+			return args[0], args[1:]
+		}
+	}
+	return "lint", args
+}
+
+// runRules is the rules subcommand: validate, print or list a rules file. It
+// returns the process exit status.
+func runRules(args []string, stdout, stderr io.Writer) int {
+	usage := "Usage: cadence-workflow-linter rules validate|print|list [--rules path]"
+	if len(args) < 1 {
+		fmt.Fprintln(stderr, usage)
+		return 1
+	}
+	action := args[0]
+
+	fs := flag.NewFlagSet("rules "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	rulesPath := fs.String("rules", "config/rules.yaml", "path to rules yaml")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 1
+	}
+
+	switch action {
+	case "validate", "print", "list":
+	default:
+		fmt.Fprintln(stderr, usage)
+		return 1
+	}
+
+	// LoadRules validates the file, so loading it is all validate needs.
+	rules, err := config.LoadRules(*rulesPath)
+	if err != nil {
+		fmt.Fprintln(stderr, "Error loading rules:", err)
+		return 1
+	}
+
+	switch action {
+	case "validate":
+		fmt.Fprintf(stdout, "%s: ok\n", *rulesPath)
+	case "print":
+		out, err := yaml.Marshal(rules)
+		if err != nil {
+			fmt.Fprintln(stderr, "Marshal error:", err)
+			return 1
+		}
+		stdout.Write(out)
+	case "list":
+		if err := writeRuleList(stdout, rules); err != nil {
+			fmt.Fprintln(stderr, "Output error:", err)
+			return 1
+		}
+	}
+	return 0
+}
+
+// runMerge is the merge subcommand: it combines json reports, e.g. from
+// sharded CI runs, into one de-duplicated report in any output format. It
+// returns the process exit status.
+func runMerge(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("merge", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "auto", "output format: auto|json|jsonl|yaml|sarif|junit|github-actions (auto follows the --output extension, json otherwise)")
+	outputPath := fs.String("output", "", "write the merged report to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(stderr, "Usage: cadence-workflow-linter merge [--format f] [--output file] <report.json>...")
+		return 1
+	}
+
+	var reports [][]detectors.Issue
+	for _, path := range fs.Args() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintln(stderr, "Error reading report:", err)
+			return 1
+		}
+		issues, err := output.ReadJSONReport(data)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error reading report %s: %v\n", path, err)
+			return 1
+		}
+		reports = append(reports, issues)
+	}
+	merged := output.MergeIssues(reports...)
+
+	f := resolveFormat(*format, *outputPath)
+	var err error
+	if *outputPath != "" {
+		err = writeReportFile(*outputPath, f, nil, merged)
+	} else {
+		err = writeReport(stdout, f, nil, merged)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "Output error:", err)
+		return 1
+	}
+	return 0
+}
+
+// writeRuleList prints every rule the linter can report, built-in or
+// configured in rules, with its category.
+func writeRuleList(w io.Writer, rules *config.RuleSet) error {
+	seen := map[string]bool{}
+	var names []string
+	for _, name := range append(detectors.BuiltinRules(), rules.RuleNames()...) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RULE\tCATEGORY")
+	for _, name := range names {
+		category := detectors.CategoryOf(name)
+		if category == "" {
+			category = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", name, category)
+	}
+	return tw.Flush()
+}
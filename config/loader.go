@@ -1,17 +1,24 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"os"
+	"regexp"
 
 	"gopkg.in/yaml.v3"
 )
 
 type FunctionRule struct {
-	Rule      string   `yaml:"rule"`
-	Package   string   `yaml:"package"`   // import path (e.g., "time", "math/rand", "fmt", "os")
-	Functions []string `yaml:"functions"` // selector names
-	Severity  string   `yaml:"severity"`  // e.g., "error", "warning"
-	Message   string   `yaml:"message"`
+	Rule            string   `yaml:"rule"`
+	Package         string   `yaml:"package"`                   // import path (e.g., "time", "math/rand", "fmt", "os")
+	PackageRegex    string   `yaml:"package_regex,omitempty"`   // matches import paths instead of package; see CompilePattern
+	Functions       []string `yaml:"functions"`                 // selector names
+	FunctionsRegex  string   `yaml:"functions_regex,omitempty"` // matches selector names in addition to functions
+	Severity        string   `yaml:"severity"`                  // e.g., "error", "warning"
+	Message         string   `yaml:"message"`
+	MessageTemplate string   `yaml:"message_template,omitempty"` // replaces the reported message; see detectors.RenderMessage
 }
 
 type ImportRule struct {
@@ -22,18 +29,30 @@ type ImportRule struct {
 }
 
 type ExternalPackageRule struct {
-	Rule      string   `yaml:"rule"`
-	Package   string   `yaml:"package"`   // full import path (e.g., "github.com/google/uuid")
-	Functions []string `yaml:"functions"` // function names to flag
-	Severity  string   `yaml:"severity"`  // e.g., "error", "warning"
-	Message   string   `yaml:"message"`   // message when violation is detected
+	Rule            string   `yaml:"rule"`
+	Package         string   `yaml:"package"`                    // full import path (e.g., "github.com/google/uuid")
+	PackageRegex    string   `yaml:"package_regex,omitempty"`    // matches import paths instead of package; see CompilePattern
+	Functions       []string `yaml:"functions"`                  // function names to flag
+	FunctionsRegex  string   `yaml:"functions_regex,omitempty"`  // matches function names in addition to functions
+	Severity        string   `yaml:"severity"`                   // e.g., "error", "warning"
+	Message         string   `yaml:"message"`                    // message when violation is detected
+	MessageTemplate string   `yaml:"message_template,omitempty"` // replaces the reported message; see detectors.RenderMessage
 }
 
 type RuleSet struct {
-	FunctionCalls        []FunctionRule        `yaml:"function_calls"`
-	DisallowedImports    []ImportRule          `yaml:"disallowed_imports"`
-	ExternalPackages     []ExternalPackageRule `yaml:"external_packages"`
-	SafeExternalPackages []string              `yaml:"safe_external_packages"`
+	FunctionCalls           []FunctionRule        `yaml:"function_calls"`
+	DisallowedImports       []ImportRule          `yaml:"disallowed_imports"`
+	ExternalPackages        []ExternalPackageRule `yaml:"external_packages"`
+	SafeExternalPackages    []string              `yaml:"safe_external_packages"`
+	GraceRules              []string              `yaml:"grace_rules"`                   // reported, but never fail the run
+	ExcludeFunctions        []string              `yaml:"exclude_functions"`             // canonical-name globs skipped by detectors
+	OpaqueExcluded          bool                  `yaml:"exclude_functions_opaque"`      // stop reachability at excluded functions
+	WorkflowContextPackages []string              `yaml:"workflow_context_packages"`     // packages whose Context type implies workflow code
+	UnknownExternalCall     string                `yaml:"unknown_external_call"`         // off|info|warning|error (default info)
+	MessageTemplates        map[string]string     `yaml:"message_templates"`             // built-in detector rule -> message template
+	UnregisteredWorkflows   bool                  `yaml:"report_unregistered_workflows"` // note workflows never registered in the scanned files
+	DedupePositions         bool                  `yaml:"dedupe_positions"`              // keep one issue per file position
+	DedupePrefer            []string              `yaml:"dedupe_prefer"`                 // rules that win dedupe_positions, most preferred first
 }
 
 func LoadRules(path string) (*RuleSet, error) {
@@ -45,5 +64,113 @@ func LoadRules(path string) (*RuleSet, error) {
 	if err := yaml.Unmarshal(b, &rs); err != nil {
 		return nil, err
 	}
+	if err := Validate(&rs); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
 	return &rs, nil
 }
+
+// Validate checks a ruleset for settings the linter can't act on. LoadRules
+// runs it on every file; rulesets built in code are checked by the linter.
+func Validate(rs *RuleSet) error {
+	switch rs.UnknownExternalCall {
+	case "", "off", "info", "warning", "error":
+	default:
+		return fmt.Errorf("invalid unknown_external_call %q (want off|info|warning|error)", rs.UnknownExternalCall)
+	}
+	if len(rs.DedupePrefer) > 0 && !rs.DedupePositions {
+		return fmt.Errorf("dedupe_prefer has no effect without dedupe_positions: true")
+	}
+	check := func(kind, rule, severity string) error {
+		switch severity {
+		case "info", "warning", "error":
+			return nil
+		}
+		return fmt.Errorf("%s rule %q: invalid severity %q (want info|warning|error)", kind, rule, severity)
+	}
+	checkPatterns := func(kind, rule, pkg, pkgRegex, funcsRegex string) error {
+		if pkg != "" && pkgRegex != "" {
+			return fmt.Errorf("%s rule %q: set package or package_regex, not both", kind, rule)
+		}
+		for _, expr := range []string{pkgRegex, funcsRegex} {
+			if expr == "" {
+				continue
+			}
+			if _, err := CompilePattern(expr); err != nil {
+				return fmt.Errorf("%s rule %q: %w", kind, rule, err)
+			}
+		}
+		return nil
+	}
+	for _, r := range rs.FunctionCalls {
+		if err := check("function_calls", r.Rule, r.Severity); err != nil {
+			return err
+		}
+		if err := checkPatterns("function_calls", r.Rule, r.Package, r.PackageRegex, r.FunctionsRegex); err != nil {
+			return err
+		}
+	}
+	for _, r := range rs.DisallowedImports {
+		if err := check("disallowed_imports", r.Rule, r.Severity); err != nil {
+			return err
+		}
+	}
+	for _, r := range rs.ExternalPackages {
+		if err := check("external_packages", r.Rule, r.Severity); err != nil {
+			return err
+		}
+		if err := checkPatterns("external_packages", r.Rule, r.Package, r.PackageRegex, r.FunctionsRegex); err != nil {
+			return err
+		}
+	}
+	for _, r := range rs.FunctionCalls {
+		if _, ok := rs.MessageTemplates[r.Rule]; ok {
+			return fmt.Errorf("message_templates: %q is a function_calls rule; set message_template on the rule instead", r.Rule)
+		}
+	}
+	for _, r := range rs.ExternalPackages {
+		if _, ok := rs.MessageTemplates[r.Rule]; ok {
+			return fmt.Errorf("message_templates: %q is an external_packages rule; set message_template on the rule instead", r.Rule)
+		}
+	}
+	return nil
+}
+
+// CompilePattern compiles a package_regex or functions_regex value. Patterns
+// must match the whole import path or function name, so "Must.*" matches
+// MustParse but not ParseMust.
+func CompilePattern(expr string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + expr + `)$`)
+}
+
+// Fingerprint returns a stable hash of the effective ruleset content.
+func (rs *RuleSet) Fingerprint() (string, error) {
+	b, err := yaml.Marshal(rs)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// RuleNames returns the distinct rule names configured in the ruleset, in declaration order.
+func (rs *RuleSet) RuleNames() []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, r := range rs.FunctionCalls {
+		add(r.Rule)
+	}
+	for _, r := range rs.DisallowedImports {
+		add(r.Rule)
+	}
+	for _, r := range rs.ExternalPackages {
+		add(r.Rule)
+	}
+	return names
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the per-repository config file the CLI discovers.
+const ProjectFileName = ".cadence-lint.yaml"
+
+// ProjectConfig holds CLI defaults checked into a repository. Every field
+// mirrors the lint flag of the same name; flags given on the command line win.
+type ProjectConfig struct {
+	Rules           string   `yaml:"rules"` // relative to the config file's directory
+	Format          string   `yaml:"format"`
+	FailOn          string   `yaml:"fail_on"`
+	MinSeverity     string   `yaml:"min_severity"`
+	Category        []string `yaml:"category"`
+	ExcludeCategory []string `yaml:"exclude_category"`
+	Workers         int      `yaml:"workers"`
+	Exclude         []string `yaml:"exclude"` // globs relative to each directory target, like --exclude
+	IncludeVendor   bool     `yaml:"include_vendor"`
+
+	Path string `yaml:"-"` // where the config was found
+}
+
+// FindProjectConfig looks for ProjectFileName in start (or start's directory,
+// if it is a file) and then in each parent directory, like the go command
+// does for go.mod. It returns nil when there is none.
+func FindProjectConfig(start string) (*ProjectConfig, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		if _, err := os.Stat(path); err == nil {
+			return LoadProjectConfig(path)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// LoadProjectConfig reads a project config file. Unknown keys are rejected,
+// and a relative rules path is resolved against the file's directory.
+func LoadProjectConfig(path string) (*ProjectConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pc ProjectConfig
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(&pc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if pc.Rules != "" && !filepath.IsAbs(pc.Rules) {
+		pc.Rules = filepath.Join(filepath.Dir(path), pc.Rules)
+	}
+	pc.Path = path
+	return &pc, nil
+}
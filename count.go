@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+// writeCount prints the total number of issues to out and, to breakdown, a
+// per-severity split such as "error=2 warning=1 info=0".
+func writeCount(out, breakdown io.Writer, issues []detectors.Issue) error {
+	bySeverity := map[string]int{}
+	for _, is := range issues {
+		bySeverity[is.Severity]++
+	}
+	if _, err := fmt.Fprintln(out, len(issues)); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(breakdown, "error=%d warning=%d info=%d\n", bySeverity["error"], bySeverity["warning"], bySeverity["info"])
+	return err
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer"
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+	"github.com/afony10/cadence-workflow-linter/linter"
+)
+
+// exitScanFailed is the exit status when the target couldn't be analyzed at
+// all, distinct from 1 for issues reaching --fail-on.
+const exitScanFailed = 2
+
+// scanErrorMessage describes a scan failure for the CLI user.
+func scanErrorMessage(err error) string {
+	var perr *analyzer.ParseError
+	switch {
+	case errors.Is(err, analyzer.ErrTargetNotFound):
+		return "Error: " + err.Error()
+	case errors.As(err, &perr):
+		return fmt.Sprintf("Parse error in %s: %v", perr.File, perr.Err)
+	}
+	return "Scan error: " + err.Error()
+}
+
+// writeParseNotice tells the user which files were left out of a scan because
+// they couldn't be parsed. It writes nothing when every file was parsed.
+func writeParseNotice(w io.Writer, errs []linter.FileError) {
+	if len(errs) == 0 {
+		return
+	}
+	if len(errs) == 1 {
+		fmt.Fprintln(w, "1 file could not be parsed and was left out of the report:")
+	} else {
+		fmt.Fprintf(w, "%d files could not be parsed and were left out of the report:\n", len(errs))
+	}
+	for _, fe := range errs {
+		fmt.Fprintf(w, "  %v\n", fe)
+	}
+}
+
+// failOnThreshold maps a --fail-on value to the minimum severity rank that
+// fails the run; "never" disables failing entirely.
+func failOnThreshold(failOn string) (int, error) {
+	switch failOn {
+	case "never":
+		return 0, nil
+	case "error", "warning", "info":
+		return detectors.SeverityRank(failOn), nil
+	}
+	return 0, fmt.Errorf("invalid --fail-on value %q (want error|warning|info|never)", failOn)
+}
+
+// exitCode returns 1 when any issue reaches the --fail-on threshold. Issues of
+// rules listed in graceRules are still reported but never count toward it.
+func exitCode(issues []detectors.Issue, failOn string, graceRules []string) (int, error) {
+	threshold, err := failOnThreshold(failOn)
+	if err != nil {
+		return 0, err
+	}
+	if threshold == 0 {
+		return 0, nil
+	}
+	grace := map[string]bool{}
+	for _, r := range graceRules {
+		grace[r] = true
+	}
+	for _, is := range issues {
+		if grace[is.Rule] {
+			continue
+		}
+		if detectors.SeverityRank(is.Severity) >= threshold {
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+// filterCategories keeps issues whose category is in the comma-separated
+// include list (all when empty) and not in the exclude list. Category names
+// match case-insensitively.
+func filterCategories(issues []detectors.Issue, include, exclude string) []detectors.Issue {
+	inc, exc := categorySet(include), categorySet(exclude)
+	if len(inc) == 0 && len(exc) == 0 {
+		return issues
+	}
+	var kept []detectors.Issue
+	for _, is := range issues {
+		cat := strings.ToLower(is.Category)
+		if len(inc) > 0 && !inc[cat] {
+			continue
+		}
+		if exc[cat] {
+			continue
+		}
+		kept = append(kept, is)
+	}
+	return kept
+}
+
+func categorySet(list string) map[string]bool {
+	set := map[string]bool{}
+	for _, c := range strings.Split(list, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			set[strings.ToLower(c)] = true
+		}
+	}
+	return set
+}
+
+// minSeverity resolves --min-severity and its --errors-only shorthand. The two
+// may be combined only when they agree.
+func minSeverity(flagValue string, errorsOnly bool) (string, error) {
+	switch flagValue {
+	case "", "info", "warning", "error":
+	default:
+		return "", fmt.Errorf("invalid --min-severity value %q (want error|warning|info)", flagValue)
+	}
+	if !errorsOnly {
+		return flagValue, nil
+	}
+	if flagValue != "" && flagValue != "error" {
+		return "", fmt.Errorf("--errors-only conflicts with --min-severity %s", flagValue)
+	}
+	return "error", nil
+}
+
+// filterSeverity keeps issues at or above severity minimum (all when empty).
+func filterSeverity(issues []detectors.Issue, minimum string) []detectors.Issue {
+	if minimum == "" {
+		return issues
+	}
+	threshold := detectors.SeverityRank(minimum)
+	var kept []detectors.Issue
+	for _, is := range issues {
+		if detectors.SeverityRank(is.Severity) >= threshold {
+			kept = append(kept, is)
+		}
+	}
+	return kept
+}
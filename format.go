@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+	"github.com/afony10/cadence-workflow-linter/output"
+)
+
+// formatsByExtension maps --output file extensions to the format --format auto picks.
+var formatsByExtension = map[string]string{
+	".json":   "json",
+	".jsonl":  "jsonl",
+	".ndjson": "jsonl",
+	".yaml":   "yaml",
+	".yml":    "yaml",
+	".sarif":  "sarif",
+	".xml":    "junit",
+}
+
+// resolveFormat returns the report format to use. "auto" follows the
+// extension of outputPath and falls back to json, including for stdout.
+func resolveFormat(format, outputPath string) string {
+	if format != "auto" {
+		return format
+	}
+	if f, ok := formatsByExtension[strings.ToLower(filepath.Ext(outputPath))]; ok {
+		return f
+	}
+	return "json"
+}
+
+// writeReportFile writes the report to path, replacing any existing file.
+func writeReportFile(path, format string, meta *output.Metadata, issues []detectors.Issue) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := writeReport(f, format, meta, issues); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
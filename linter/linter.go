@@ -0,0 +1,228 @@
+// Package linter is the library entry point for embedding the workflow linter
+// in other tools, such as editors that already hold parsed ASTs.
+package linter
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer"
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+	"github.com/afony10/cadence-workflow-linter/analyzer/modutils"
+	"github.com/afony10/cadence-workflow-linter/config"
+)
+
+// Options configures a lint run.
+type Options struct {
+	// Rules is the ruleset to apply. Rulesets built in code are checked with
+	// config.Validate before use. Rules takes precedence over RulesPath.
+	Rules *config.RuleSet
+
+	// RulesPath is a rules YAML file, loaded when Rules is nil.
+	RulesPath string
+
+	// Module describes the module the parsed files belong to, so calls into
+	// its own packages aren't reported as unknown external calls. Only used
+	// by LintParsed; Lint reads go.mod itself.
+	Module *modutils.ModuleInfo
+
+	// Classifier, if set, overrides how imported packages are classified
+	// (stdlib, internal, safe or unknown external). Packages it leaves
+	// PackageUndecided fall back to detectors.DefaultClassifier.
+	Classifier detectors.PackageClassifier
+
+	// NoBuiltinRules runs only the rules defined in Rules (function calls and
+	// disallowed imports), skipping the built-in detectors such as the
+	// goroutine and channel checks.
+	NoBuiltinRules bool
+
+	// Func restricts reported issues to one function, given by canonical or
+	// package-relative name; FuncTransitive adds the functions it calls.
+	Func           string
+	FuncTransitive bool
+
+	// FollowSymlinks makes directory targets descend into symlinked
+	// directories; each directory is still walked only once.
+	FollowSymlinks bool
+
+	// Exclude are glob patterns of files skipped in directory targets, e.g.
+	// "**/mocks/**" or "*_gen.go". Vendor directories are skipped unless
+	// IncludeVendor is set.
+	Exclude       []string
+	IncludeVendor bool
+
+	// GOOS and GOARCH select files by build constraints in directory
+	// targets, as the go command would for that platform; empty means the
+	// host's.
+	GOOS   string
+	GOARCH string
+
+	// Workers is how many files are analyzed concurrently; zero means
+	// GOMAXPROCS. Issues come back in the same order whatever its value.
+	Workers int
+}
+
+// Result holds the outcome of a lint run. Issues are sorted by file, line,
+// column, rule and message. ParseErrors lists the files that couldn't be
+// parsed and were left out; Issues covers the rest.
+type Result struct {
+	Issues      []detectors.Issue
+	ParseErrors []FileError
+}
+
+// FileError is a file a lint run couldn't analyze.
+type FileError struct {
+	File string
+	Err  error
+}
+
+func (e FileError) Error() string { return e.File + ": " + e.Err.Error() }
+
+func (e FileError) Unwrap() error { return e.Err }
+
+// HasErrors reports whether some files couldn't be analyzed, i.e. whether
+// Issues is only a partial result.
+func (r Result) HasErrors() bool { return len(r.ParseErrors) > 0 }
+
+// Err joins ParseErrors into one error, or returns nil when every file was
+// analyzed.
+func (r Result) Err() error {
+	errs := make([]error, len(r.ParseErrors))
+	for i, fe := range r.ParseErrors {
+		errs[i] = fe
+	}
+	return errors.Join(errs...)
+}
+
+// Detectors returns a factory producing fresh detectors per file for the given rules.
+func Detectors(rules *config.RuleSet) func(*modutils.ModuleInfo) []ast.Visitor {
+	return detectorsFor(Options{Rules: rules})
+}
+
+func detectorsFor(opts Options) func(*modutils.ModuleInfo) []ast.Visitor {
+	rules := opts.Rules
+	return func(moduleInfo *modutils.ModuleInfo) []ast.Visitor {
+		funcCalls := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, moduleInfo)
+		funcCalls.SetUnknownExternalCall(rules.UnknownExternalCall)
+		if opts.Classifier != nil {
+			funcCalls.SetPackageClassifier(opts.Classifier)
+		}
+		visitors := []ast.Visitor{
+			funcCalls,
+			detectors.NewImportDetector(rules.DisallowedImports),
+		}
+		if opts.NoBuiltinRules {
+			return visitors
+		}
+		visitors = append(visitors,
+			detectors.NewGoroutineDetector(),
+			detectors.NewChannelDetector(),
+			detectors.NewActivityArgDetector(),
+			detectors.NewSyncMapDetector(),
+			detectors.NewEmbedFSDetector(),
+			detectors.NewDeferLoopDetector(rules.FunctionCalls),
+			detectors.NewPostCallMutationDetector(),
+			detectors.NewBusyWaitDetector(),
+			detectors.NewPanicDetector(),
+			detectors.NewClockDetector(),
+			detectors.NewTimerStopDetector(),
+			detectors.NewGlobalStateDetector(),
+			detectors.NewGlobalVarDetector(),
+			detectors.NewMapRangeDetector(),
+			detectors.NewReceiverMutationDetector(),
+			detectors.NewSyncPrimitiveDetector(),
+			detectors.NewContextMisuseDetector(),
+			detectors.NewActivityTimeoutDetector(),
+			detectors.NewDirectActivityCallDetector(),
+			detectors.NewPlatformDependentDetector(),
+			detectors.NewPointerIdentityDetector(),
+			detectors.NewUnawaitedGoDetector(),
+			detectors.NewUnknownActivityNameDetector(),
+		)
+		if rules.UnregisteredWorkflows {
+			visitors = append(visitors, detectors.NewUnregisteredWorkflowDetector())
+		}
+		return visitors
+	}
+}
+
+// resolveRules returns opts with Rules set and validated, loading RulesPath if needed.
+func resolveRules(opts Options) (Options, error) {
+	if opts.Rules == nil {
+		if opts.RulesPath == "" {
+			return opts, errors.New("linter: no rules configured")
+		}
+		rules, err := config.LoadRules(opts.RulesPath)
+		if err != nil {
+			return opts, err
+		}
+		opts.Rules = rules
+		return opts, nil
+	}
+	if err := config.Validate(opts.Rules); err != nil {
+		return opts, fmt.Errorf("linter: %w", err)
+	}
+	return opts, nil
+}
+
+func scanOptions(opts Options) analyzer.Options {
+	rules := opts.Rules
+	return analyzer.Options{
+		Workers:                 opts.Workers,
+		FollowSymlinks:          opts.FollowSymlinks,
+		Exclude:                 opts.Exclude,
+		IncludeVendor:           opts.IncludeVendor,
+		GOOS:                    opts.GOOS,
+		GOARCH:                  opts.GOARCH,
+		Func:                    opts.Func,
+		FuncTransitive:          opts.FuncTransitive,
+		MessageTemplates:        rules.MessageTemplates,
+		ExcludeFunctions:        rules.ExcludeFunctions,
+		OpaqueExcluded:          rules.OpaqueExcluded,
+		WorkflowContextPackages: rules.WorkflowContextPackages,
+		DedupePositions:         rules.DedupePositions,
+		PreferredRules:          rules.DedupePrefer,
+	}
+}
+
+// Lint reads and analyzes a file or directory.
+func Lint(target string, opts Options) (Result, error) {
+	return LintTargets([]string{target}, opts)
+}
+
+// LintTargets reads and analyzes several files and directories together, so
+// calls from workflows in one target into helpers in another are followed.
+// Files that fail to parse don't stop the run; they are reported in
+// Result.ParseErrors and the remaining files are still analyzed.
+func LintTargets(targets []string, opts Options) (Result, error) {
+	opts, err := resolveRules(opts)
+	if err != nil {
+		return Result{}, err
+	}
+	issues, parseErrs, err := analyzer.ScanTargetsPartial(targets, detectorsFor(opts), scanOptions(opts))
+	if err != nil {
+		return Result{}, err
+	}
+	res := Result{Issues: issues}
+	for _, pe := range parseErrs {
+		res.ParseErrors = append(res.ParseErrors, FileError{File: pe.File, Err: pe.Err})
+	}
+	return res, nil
+}
+
+// LintParsed analyzes files already parsed into fset without touching the
+// filesystem. pkgPaths maps each file to its import path, which keys the
+// cross-package call graph; files missing from it use their package name.
+func LintParsed(files []*ast.File, fset *token.FileSet, pkgPaths map[*ast.File]string, opts Options) (Result, error) {
+	opts, err := resolveRules(opts)
+	if err != nil {
+		return Result{}, err
+	}
+	issues, err := analyzer.ScanParsed(files, fset, pkgPaths, opts.Module, detectorsFor(opts), scanOptions(opts))
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Issues: issues}, nil
+}
@@ -0,0 +1,434 @@
+package linter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer"
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+	"github.com/afony10/cadence-workflow-linter/analyzer/modutils"
+	"github.com/afony10/cadence-workflow-linter/config"
+)
+
+func TestLintParsed(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	srcs := map[string]string{
+		"/virtual/app/workflow.go": `package app
+
+import (
+	"go.uber.org/cadence/workflow"
+	"example.com/svc/clock"
+)
+
+func OrderWorkflow(ctx workflow.Context) error {
+	_ = clock.Stamp()
+	return nil
+}
+`,
+		"/virtual/clock/clock.go": `package clock
+
+import "time"
+
+func Stamp() time.Time { return time.Now() }
+
+func Unused() time.Time { return time.Now() }
+`,
+	}
+
+	fset := token.NewFileSet()
+	pkgPaths := map[*ast.File]string{}
+	var files []*ast.File
+	for name, src := range srcs {
+		f, err := parser.ParseFile(fset, name, src, parser.AllErrors)
+		if err != nil {
+			t.Fatalf("parse %s: %v", name, err)
+		}
+		files = append(files, f)
+		pkgPaths[f] = "example.com/svc/" + f.Name.Name
+	}
+
+	res, err := LintParsed(files, fset, pkgPaths, Options{Rules: rules, Module: &modutils.ModuleInfo{ModulePath: "example.com/svc"}})
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	if len(res.Issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d: %+v", len(res.Issues), res.Issues)
+	}
+	is := res.Issues[0]
+	if is.Rule != "TimeUsage" || is.File != "/virtual/clock/clock.go" || is.Line != 5 {
+		t.Fatalf("unexpected issue: %+v", is)
+	}
+}
+
+func TestLintRequiresRules(t *testing.T) {
+	if _, err := LintParsed(nil, token.NewFileSet(), nil, Options{}); err == nil {
+		t.Fatal("expected an error without rules")
+	}
+}
+
+func TestLintInCodeRuleSet(t *testing.T) {
+	src := `package app
+
+import (
+	"math/rand"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func PickWorkflow(ctx workflow.Context) int { return rand.Intn(10) }
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "/virtual/app/pick.go", src, parser.AllErrors)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rules := &config.RuleSet{
+		FunctionCalls: []config.FunctionRule{
+			{Rule: "RandomUsage", Package: "math/rand", Functions: []string{"Intn"}, Severity: "error", Message: "no rand"},
+		},
+	}
+
+	// An in-code ruleset wins over RulesPath, which would fail to load.
+	opts := Options{Rules: rules, RulesPath: "/nonexistent/rules.yaml"}
+	res, err := LintParsed([]*ast.File{f}, fset, nil, opts)
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	if len(res.Issues) != 1 || res.Issues[0].Rule != "RandomUsage" || res.Issues[0].Message != "no rand" {
+		t.Fatalf("unexpected issues: %+v", res.Issues)
+	}
+
+	rules.FunctionCalls[0].Severity = "fatal"
+	if _, err := LintParsed([]*ast.File{f}, fset, nil, opts); err == nil || !strings.Contains(err.Error(), "invalid severity") {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+}
+
+func TestLintOutputIndependentOfWorkers(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	var outputs [][]byte
+	for _, workers := range []int{1, 8} {
+		res, err := Lint("../testdata", Options{Rules: rules, Workers: workers})
+		if err != nil {
+			t.Fatalf("lint with %d workers: %v", workers, err)
+		}
+		out, err := json.MarshalIndent(res.Issues, "", "  ")
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		outputs = append(outputs, out)
+	}
+	if len(outputs[0]) < 100 {
+		t.Fatalf("expected issues from testdata, got %s", outputs[0])
+	}
+	if !bytes.Equal(outputs[0], outputs[1]) {
+		t.Fatal("JSON output differs between 1 and 8 workers")
+	}
+}
+
+func TestLintSingleFunction(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	res, err := Lint("../testdata", Options{Rules: rules, Func: "GoroutineWorkflow"})
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	if len(res.Issues) == 0 {
+		t.Fatal("expected issues in GoroutineWorkflow")
+	}
+	for _, is := range res.Issues {
+		if is.Func != "GoroutineWorkflow" {
+			t.Errorf("issue outside the selected function: %+v", is)
+		}
+	}
+
+	direct, err := Lint("../testdata", Options{Rules: rules, Func: "CrossFileWorkflow"})
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	if len(direct.Issues) != 0 {
+		t.Fatalf("expected no issues in CrossFileWorkflow itself, got %+v", direct.Issues)
+	}
+	transitive, err := Lint("../testdata", Options{Rules: rules, Func: "CrossFileWorkflow", FuncTransitive: true})
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	if len(transitive.Issues) == 0 || transitive.Issues[0].Func != "CrossFileHelper" {
+		t.Fatalf("expected issues from CrossFileHelper, got %+v", transitive.Issues)
+	}
+}
+
+func TestLintBuildConstraints(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	funcs := func(goos, goarch string) []string {
+		t.Helper()
+		res, err := Lint("../testdata/build_constraints", Options{Rules: rules, GOOS: goos, GOARCH: goarch})
+		if err != nil {
+			t.Fatalf("lint: %v", err)
+		}
+		var got []string
+		for _, is := range res.Issues {
+			got = append(got, is.Func)
+		}
+		return got
+	}
+
+	if got := funcs("linux", "amd64"); len(got) != 0 {
+		t.Errorf("linux/amd64: expected windows-only files to be skipped, got issues in %v", got)
+	}
+	if got, want := funcs("windows", "arm64"), []string{"WindowsWorkflow"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("windows/arm64: got %v, want %v", got, want)
+	}
+	if got, want := funcs("windows", "amd64"), []string{"WindowsWorkflow", "TaggedWorkflow"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("windows/amd64: got %v, want %v", got, want)
+	}
+}
+
+func TestLintMessageTemplates(t *testing.T) {
+	src := `package app
+
+import (
+	"math/rand"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func PickWorkflow(ctx workflow.Context) int {
+	go func() {}()
+	return rand.Intn(10)
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "/virtual/app/pick.go", src, parser.AllErrors)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rules := &config.RuleSet{
+		FunctionCalls: []config.FunctionRule{{
+			Rule: "Randomness", Package: "math/rand", Functions: []string{"Intn"}, Severity: "error",
+			Message:         "Detected rand.%FUNC%()",
+			MessageTemplate: "%MESSAGE% [%PKG%.%FUNC% at %FILE%:%LINE%] see https://wiki.example.com/cadence#rand",
+		}},
+		MessageTemplates: map[string]string{
+			"Concurrency": "%FUNC% in %PKG%: %MESSAGE%",
+		},
+	}
+	pkgPaths := map[*ast.File]string{f: "example.com/app"}
+	res, err := LintParsed([]*ast.File{f}, fset, pkgPaths, Options{Rules: rules})
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	want := map[string]string{
+		"Concurrency": "PickWorkflow in example.com/app: Detected goroutine. Use workflow.Go(ctx) inside workflows.",
+		"Randomness":  "Detected rand.Intn() [math/rand.Intn at /virtual/app/pick.go:11] see https://wiki.example.com/cadence#rand",
+	}
+	if len(res.Issues) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), res.Issues)
+	}
+	for _, is := range res.Issues {
+		if is.Message != want[is.Rule] {
+			t.Errorf("%s: got message %q, want %q", is.Rule, is.Message, want[is.Rule])
+		}
+	}
+
+	rules.MessageTemplates["Randomness"] = "%MESSAGE%"
+	if _, err := LintParsed([]*ast.File{f}, fset, pkgPaths, Options{Rules: rules}); err == nil {
+		t.Fatal("expected message_templates for a function_calls rule to be rejected")
+	}
+}
+
+type internalPackages map[string]bool
+
+func (p internalPackages) Classify(importPath string) detectors.PackageClass {
+	if p[importPath] {
+		return detectors.PackageInternal
+	}
+	return detectors.PackageUndecided
+}
+
+func TestLintParsedCustomClassifier(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "/virtual/app/workflow.go", `package app
+
+import (
+	"go.uber.org/cadence/workflow"
+	"corp.example/platform/ids"
+	"corp.example/other/lib"
+)
+
+func SignupWorkflow(ctx workflow.Context) error {
+	_ = ids.Next()
+	_ = lib.Call()
+	return nil
+}
+`, parser.AllErrors)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	opts := Options{Rules: rules, Classifier: internalPackages{"corp.example/platform/ids": true}}
+	res, err := LintParsed([]*ast.File{f}, fset, map[*ast.File]string{f: "corp.example/app"}, opts)
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	if len(res.Issues) != 1 || res.Issues[0].Rule != "UnknownExternalCall" || !strings.Contains(res.Issues[0].Message, "corp.example/other/lib") {
+		t.Fatalf("expected only the unclassified package to be reported, got %+v", res.Issues)
+	}
+}
+
+func TestLintClosures(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	res, err := Lint("../testdata/closure_violation.go", Options{Rules: rules})
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	var lines []int
+	for _, is := range res.Issues {
+		if is.Rule == "TimeUsage" {
+			lines = append(lines, is.Line)
+		}
+	}
+	// Closures run by the workflow are flagged, even one taking a
+	// context.Context; the local activity literal and the helper only it
+	// calls are not.
+	if want := []int{16, 20, 25}; !reflect.DeepEqual(lines, want) {
+		t.Fatalf("expected TimeUsage on lines %v, got %+v", want, res.Issues)
+	}
+}
+
+func TestLintSuppressions(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	res, err := Lint("../testdata/suppression_violation.go", Options{Rules: rules})
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	var got []string
+	for _, is := range res.Issues {
+		if is.Rule == "TimeUsage" || is.Rule == "Randomness" {
+			got = append(got, fmt.Sprintf("%d:%s", is.Line, is.Rule))
+		}
+	}
+	// A directive covers its own line and the next one, and only the rules it names.
+	if want := []string{"14:TimeUsage", "16:Randomness"}; strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("got %v, want %v (issues %+v)", got, want, res.Issues)
+	}
+}
+
+func TestLintPartialParse(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+	root := t.TempDir()
+	broken := filepath.Join(root, "broken.go")
+	if err := os.WriteFile(broken, []byte("package app\n\nfunc Broken( {\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wf := "package app\n\nimport (\n\t\"time\"\n\n\t\"go.uber.org/cadence/workflow\"\n)\n\nfunc ClockWorkflow(ctx workflow.Context) error {\n\t_ = time.Now()\n\treturn nil\n}\n"
+	if err := os.WriteFile(filepath.Join(root, "workflow.go"), []byte(wf), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := Lint(root, Options{Rules: rules})
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	if len(res.Issues) != 1 || res.Issues[0].Rule != "TimeUsage" {
+		t.Errorf("expected the parsable file's TimeUsage issue, got %+v", res.Issues)
+	}
+	if !res.HasErrors() || len(res.ParseErrors) != 1 || res.ParseErrors[0].File != broken {
+		t.Fatalf("expected one parse error for %s, got %+v", broken, res.ParseErrors)
+	}
+	if err := res.Err(); err == nil || !strings.Contains(err.Error(), "broken.go") {
+		t.Errorf("expected Err to name the broken file, got %v", err)
+	}
+}
+
+func TestDetectorsScanFileSmoke(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	issues, err := analyzer.ScanFile("../testdata/time_violation.go", Detectors(rules))
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if len(issues) == 0 {
+		t.Fatal("expected issues in time_violation.go")
+	}
+	for _, is := range issues {
+		if is.Func == "ValidActivity" {
+			t.Errorf("unexpected issue outside workflow code: %+v", is)
+		}
+	}
+}
+
+func TestLintNoBuiltinRules(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+	root := t.TempDir()
+	wf := "package app\n\nimport (\n\t\"time\"\n\n\t\"go.uber.org/cadence/workflow\"\n)\n\nfunc MixedWorkflow(ctx workflow.Context) error {\n\tch := make(chan int)\n\tgo func() { ch <- 1 }()\n\t<-ch\n\t_ = time.Now()\n\treturn nil\n}\n"
+	if err := os.WriteFile(filepath.Join(root, "workflow.go"), []byte(wf), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ruleCounts := func(opts Options) map[string]int {
+		t.Helper()
+		res, err := Lint(root, opts)
+		if err != nil {
+			t.Fatalf("lint: %v", err)
+		}
+		counts := map[string]int{}
+		for _, is := range res.Issues {
+			counts[is.Rule]++
+		}
+		return counts
+	}
+
+	if got := ruleCounts(Options{Rules: rules}); got["Concurrency"] == 0 || got["TimeUsage"] != 1 {
+		t.Fatalf("expected built-in Concurrency issues and one TimeUsage issue, got %v", got)
+	}
+	if got, want := ruleCounts(Options{Rules: rules, NoBuiltinRules: true}), map[string]int{"TimeUsage": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("with NoBuiltinRules: got %v, want %v", got, want)
+	}
+}
@@ -1,35 +1,157 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/afony10/cadence-workflow-linter/analyzer"
 	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
-	"github.com/afony10/cadence-workflow-linter/analyzer/modutils"
+	"github.com/afony10/cadence-workflow-linter/analyzer/fix"
 	"github.com/afony10/cadence-workflow-linter/config"
-
-	"go/ast"
+	"github.com/afony10/cadence-workflow-linter/linter"
+	"github.com/afony10/cadence-workflow-linter/output"
+	"github.com/afony10/cadence-workflow-linter/vcs"
+	"github.com/afony10/cadence-workflow-linter/watch"
 )
 
 func main() {
-	// Command-line flags
+	cmd, args := subcommand(os.Args[1:])
+	switch cmd {
+	case "rules":
+		os.Exit(runRules(args, os.Stdout, os.Stderr))
+	case "merge":
+		os.Exit(runMerge(args, os.Stdout, os.Stderr))
+	case "version":
+		fmt.Println(analyzer.ToolVersion())
+	default:
+		runLint(args)
+	}
+}
+
+// runLint is the lint subcommand: it scans args' targets and exits with the
+// status --fail-on asks for.
+func runLint(args []string) {
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
 	var format string
 	var rulesPath string
-	flag.StringVar(&format, "format", "json", "output format: json|yaml")
-	flag.StringVar(&rulesPath, "rules", "config/rules.yaml", "path to rules yaml")
-	flag.Parse()
+	var showFixes bool
+	var applyFixes bool
+	var failOn string
+	var ruleStats bool
+	var countOnly bool
+	var watchMode bool
+	var category string
+	var excludeCategory string
+	var targetsFrom string
+	var workers int
+	var funcName string
+	var funcTransitive bool
+	var printSchema bool
+	var listFiles bool
+	var followSymlinks bool
+	var excludes stringList
+	var includeVendor bool
+	var goos, goarch string
+	var noBuiltinRules bool
+	var minSeverityFlag string
+	var errorsOnly bool
+	var gitMetadata bool
+	var execReporter string
+	var outputPath string
+	fs.StringVar(&format, "format", "auto", "output format: auto|json|jsonl|yaml|sarif|junit|github-actions (auto follows the --output extension, json otherwise)")
+	fs.StringVar(&rulesPath, "rules", "config/rules.yaml", "path to rules yaml")
+	fs.BoolVar(&showFixes, "fix", false, "print suggested fixes as a unified diff instead of the report")
+	fs.BoolVar(&applyFixes, "fix-apply", false, "apply high-confidence suggested fixes in place (originals kept as *.orig) and report what remains")
+	fs.StringVar(&failOn, "fail-on", "error", "exit non-zero when an issue of this severity or higher is found: error|warning|info|never")
+	fs.BoolVar(&ruleStats, "rule-stats", false, "print how often each rule fired and in how many files instead of the report")
+	fs.BoolVar(&countOnly, "count", false, "print only the number of issues (per-severity breakdown on stderr) instead of the report")
+	fs.BoolVar(&watchMode, "watch", false, "re-lint whenever .go files under the target change (interactive use only)")
+	fs.StringVar(&category, "category", "", "only report rules in these comma-separated categories: Determinism,Concurrency,IO,Reliability")
+	fs.StringVar(&excludeCategory, "exclude-category", "", "don't report rules in these comma-separated categories")
+	fs.StringVar(&targetsFrom, "targets-from", "", "read newline-separated files/directories to scan from this file (- for stdin)")
+	fs.IntVar(&workers, "workers", 0, "number of files to analyze concurrently (0 = number of CPUs); output order doesn't depend on it")
+	fs.StringVar(&funcName, "func", "", "only report issues inside this function (canonical pkg/path.Func or short Func / (Type).Method name)")
+	fs.BoolVar(&funcTransitive, "func-transitive", false, "with --func, also report issues in the functions it calls")
+	fs.BoolVar(&printSchema, "print-schema", false, "print the JSON Schema of the json/jsonl report and exit")
+	fs.BoolVar(&listFiles, "list-files", false, "print the files that would be scanned and exit without parsing them")
+	fs.Var(&excludes, "exclude", "skip files matching this glob in directory targets, e.g. '**/mocks/**' or '*_gen.go' (repeatable)")
+	fs.BoolVar(&includeVendor, "include-vendor", false, "also scan vendor directories, which are skipped by default")
+	fs.StringVar(&goos, "goos", runtime.GOOS, "skip files whose build constraints exclude this GOOS in directory targets")
+	fs.StringVar(&goarch, "goarch", runtime.GOARCH, "skip files whose build constraints exclude this GOARCH in directory targets")
+	fs.BoolVar(&noBuiltinRules, "no-builtin-rules", false, "run only the function-call and import rules from --rules, skipping the built-in detectors")
+	fs.BoolVar(&followSymlinks, "follow-symlinks", false, "descend into symlinked directories when walking targets (each directory is visited once)")
+	fs.StringVar(&minSeverityFlag, "min-severity", "", "only report issues of this severity or higher: error|warning|info")
+	fs.BoolVar(&errorsOnly, "errors-only", false, "only report error-severity issues (shorthand for --min-severity error)")
+	fs.BoolVar(&gitMetadata, "git-metadata", false, "wrap json/yaml reports in an envelope with the git HEAD commit and branch")
+	fs.StringVar(&execReporter, "exec-reporter", "", "run this shell command with the JSON report on its stdin instead of printing the report, and exit with its status")
+	fs.StringVar(&outputPath, "output", "", "write the report to this file instead of stdout")
+	fs.Parse(args)
+
+	if printSchema {
+		if err := output.WriteSchema(os.Stdout); err != nil {
+			fmt.Println("Output error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	targets := fs.Args()
+	if targetsFrom != "" {
+		listed, err := readTargets(targetsFrom, os.Stdin)
+		if err != nil {
+			fmt.Println("Error reading targets:", err)
+			os.Exit(1)
+		}
+		targets = append(targets, listed...)
+	}
 
-	if flag.NArg() < 1 {
-		fmt.Println("Usage: cadence-workflow-linter [--format json|yaml] [--rules path] <file_or_directory>")
+	if len(targets) < 1 {
+		fmt.Println("Usage: cadence-workflow-linter [lint] [--format auto|json|jsonl|yaml|sarif|junit|github-actions] [--output file] [--git-metadata] [--exec-reporter cmd] [--rules path] [--no-builtin-rules] [--fix|--fix-apply] [--fail-on severity] [--min-severity severity|--errors-only] [--rule-stats|--count] [--watch] [--category list] [--exclude-category list] [--targets-from file] [--list-files] [--exclude glob]... [--include-vendor] [--follow-symlinks] [--goos os] [--goarch arch] [--workers n] [--func name [--func-transitive]] <file_or_directory>...")
 		os.Exit(1)
 	}
 
-	target := flag.Arg(0)
+	pc, err := config.FindProjectConfig(targets[0])
+	if err == nil && pc != nil {
+		err = applyProjectConfig(fs, pc)
+	}
+	if err != nil {
+		fmt.Println("Error loading "+config.ProjectFileName+":", err)
+		os.Exit(1)
+	}
+
+	if listFiles {
+		files, err := analyzer.ListFiles(targets, analyzer.Options{FollowSymlinks: followSymlinks, Exclude: excludes, IncludeVendor: includeVendor, GOOS: goos, GOARCH: goarch})
+		if err != nil {
+			fmt.Println(scanErrorMessage(err))
+			os.Exit(exitScanFailed)
+		}
+		for _, f := range files {
+			fmt.Println(f)
+		}
+		return
+	}
+
+	if _, err := failOnThreshold(failOn); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	severity, err := minSeverity(minSeverityFlag, errorsOnly)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
 
 	rules, err := config.LoadRules(rulesPath)
 	if err != nil {
@@ -37,47 +159,196 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Factory returns fresh visitors per file using config and module info
-	factory := func(moduleInfo *modutils.ModuleInfo) []ast.Visitor {
-		return []ast.Visitor{
-			detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, moduleInfo),
-			detectors.NewImportDetector(rules.DisallowedImports),
-			detectors.NewGoroutineDetector(),
-			detectors.NewChannelDetector(),
-		}
+	format = resolveFormat(format, outputPath)
+
+	var meta *output.Metadata
+	if gitMetadata {
+		meta = &output.Metadata{Git: vcs.Head(vcs.ExecGit{})}
 	}
 
-	var issues []detectors.Issue
-	info, statErr := os.Stat(target)
-	if statErr != nil {
-		fmt.Println("Error:", statErr)
-		os.Exit(1)
+	// incomplete records that the last scan left out files it couldn't parse.
+	incomplete := false
+	scan := func() ([]detectors.Issue, error) {
+		res, err := linter.LintTargets(targets, linter.Options{Rules: rules, NoBuiltinRules: noBuiltinRules, Workers: workers, Func: funcName, FuncTransitive: funcTransitive, FollowSymlinks: followSymlinks, Exclude: excludes, IncludeVendor: includeVendor, GOOS: goos, GOARCH: goarch})
+		incomplete = res.HasErrors()
+		writeParseNotice(os.Stderr, res.ParseErrors)
+		return filterSeverity(filterCategories(res.Issues, category, excludeCategory), severity), err
+	}
+	// exit ends the run with the --fail-on status for issues, or with
+	// exitScanFailed when files were left out.
+	exit := func(issues []detectors.Issue) {
+		code, _ := exitCode(issues, failOn, rules.GraceRules)
+		if incomplete {
+			code = exitScanFailed
+		}
+		os.Exit(code)
 	}
 
-	if info.IsDir() {
-		issues, err = analyzer.ScanDirectory(target, factory)
-	} else {
-		issues, err = analyzer.ScanFile(target, factory)
+	if watchMode {
+		if len(targets) != 1 {
+			fmt.Println("Error: --watch takes a single target")
+			os.Exit(1)
+		}
+		if wErr := runWatch(targets[0], format, meta, scan); wErr != nil {
+			fmt.Println("Error:", wErr)
+			os.Exit(1)
+		}
+		return
 	}
+
+	issues, err := scan()
 	if err != nil {
-		fmt.Println("Scan error:", err)
+		fmt.Println(scanErrorMessage(err))
+		os.Exit(exitScanFailed)
+	}
+
+	if applyFixes {
+		files, applied, aErr := fix.Apply(issues)
+		if aErr != nil {
+			fmt.Println("Fix error:", aErr)
+			os.Exit(1)
+		}
+		// Re-run the linter so the report reflects the rewritten files
+		if issues, err = scan(); err != nil {
+			fmt.Println(scanErrorMessage(err))
+			os.Exit(exitScanFailed)
+		}
+		fmt.Fprintf(os.Stderr, "Applied %d fixes to %d files; %d issues remain\n", applied, len(files), len(issues))
+	}
+
+	if showFixes {
+		diff, dErr := fix.Diff(issues)
+		if dErr != nil {
+			fmt.Println("Fix error:", dErr)
+			os.Exit(1)
+		}
+		fmt.Print(diff)
+		return
+	}
+
+	if countOnly {
+		if wErr := writeCount(os.Stdout, os.Stderr, issues); wErr != nil {
+			fmt.Println("Output error:", wErr)
+			os.Exit(1)
+		}
+		exit(issues)
+	}
+
+	if ruleStats {
+		if wErr := output.WriteRuleStats(os.Stdout, output.RuleStats(issues, rules.RuleNames())); wErr != nil {
+			fmt.Println("Output error:", wErr)
+			os.Exit(1)
+		}
+		exit(issues)
+	}
+
+	if execReporter != "" {
+		code, rErr := runReporter(execReporter, meta, issues, os.Stdout, os.Stderr)
+		if rErr != nil {
+			fmt.Println("Reporter error:", rErr)
+			os.Exit(1)
+		}
+		os.Exit(code)
+	}
+
+	if outputPath != "" {
+		if wErr := writeReportFile(outputPath, format, meta, issues); wErr != nil {
+			fmt.Println("Output error:", wErr)
+			os.Exit(1)
+		}
+	} else if wErr := writeReport(os.Stdout, format, meta, issues); wErr != nil {
+		fmt.Println("Marshal error:", wErr)
 		os.Exit(1)
 	}
 
+	exit(issues)
+}
+
+// writeReport renders issues in the selected --format. With meta, json and
+// yaml reports are wrapped in an output.Report envelope.
+func writeReport(w io.Writer, format string, meta *output.Metadata, issues []detectors.Issue) error {
+	var report any = issues
+	if meta != nil {
+		report = output.Report{Metadata: meta, Issues: issues}
+	}
 	switch format {
 	case "yaml", "yml":
-		out, mErr := yaml.Marshal(issues)
-		if mErr != nil {
-			fmt.Println("Marshal error:", mErr)
-			os.Exit(1)
+		out, err := yaml.Marshal(report)
+		if err != nil {
+			return err
 		}
-		fmt.Print(string(out))
+		_, err = w.Write(out)
+		return err
+	case "github-actions":
+		_, err := io.WriteString(w, output.ToGitHubActions(issues))
+		return err
+	case "sarif":
+		return output.ToSARIF(w, issues)
+	case "junit":
+		out, err := output.ToJUnit(issues)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	case "jsonl", "ndjson":
+		return output.ToJSONL(w, issues)
 	default:
-		out, mErr := json.MarshalIndent(issues, "", "  ")
-		if mErr != nil {
-			fmt.Println("Marshal error:", mErr)
-			os.Exit(1)
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
 		}
-		fmt.Print(string(out))
+		_, err = w.Write(out)
+		return err
+	}
+}
+
+// runWatch re-lints target on every change and prints a fresh report until interrupted.
+func runWatch(target, format string, meta *output.Metadata, scan func() ([]detectors.Issue, error)) error {
+	if !interactive() {
+		return fmt.Errorf("--watch is for interactive use; it is disabled when CI is set or stdout is not a terminal")
+	}
+	dir := target
+	if fi, err := os.Stat(target); err == nil && !fi.IsDir() {
+		dir = filepath.Dir(target)
+	}
+	src, err := watch.NewFSSource(dir)
+	if err != nil {
+		return err
 	}
+	defer src.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return watch.Run(ctx, src, watch.DefaultDebounce, func() {
+		fmt.Fprintf(os.Stderr, "\n=== %s: linting %s ===\n", time.Now().Format("15:04:05"), target)
+		issues, err := scan()
+		if err != nil {
+			fmt.Println(scanErrorMessage(err))
+			return
+		}
+		if err := writeReport(os.Stdout, format, meta, issues); err != nil {
+			fmt.Println("Marshal error:", err)
+		}
+		fmt.Fprintf(os.Stderr, "%d issues; watching for changes (Ctrl-C to stop)\n", len(issues))
+	})
+}
+
+// interactive reports whether the linter runs in a terminal outside CI.
+func interactive() bool {
+	if os.Getenv("CI") != "" {
+		return false
+	}
+	fi, err := os.Stdout.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
 }
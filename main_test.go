@@ -0,0 +1,350 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+	"github.com/afony10/cadence-workflow-linter/config"
+)
+
+func TestExitCodeGraceRules(t *testing.T) {
+	issues := []detectors.Issue{
+		{Rule: "BrandNewRule", Severity: "error"},
+		{Rule: "IOCalls", Severity: "warning"},
+	}
+
+	code, err := exitCode(issues, "error", []string{"BrandNewRule"})
+	if err != nil || code != 0 {
+		t.Fatalf("grace-listed error must not fail the run, got code=%d err=%v", code, err)
+	}
+
+	code, _ = exitCode(issues, "error", nil)
+	if code != 1 {
+		t.Fatalf("expected exit code 1 without grace rules, got %d", code)
+	}
+
+	code, _ = exitCode(issues, "warning", []string{"BrandNewRule"})
+	if code != 1 {
+		t.Fatalf("non-grace warning should still fail with --fail-on warning, got %d", code)
+	}
+
+	if _, err := exitCode(issues, "sometimes", nil); err == nil {
+		t.Fatal("expected an error for an invalid --fail-on value")
+	}
+}
+
+func TestExitCodeThresholds(t *testing.T) {
+	errs := []detectors.Issue{{Rule: "TimeUsage", Severity: "warning"}, {Rule: "Concurrency", Severity: "error"}}
+	warnings := []detectors.Issue{{Rule: "IOCalls", Severity: "info"}, {Rule: "TimeUsage", Severity: "warning"}}
+	infos := []detectors.Issue{{Rule: "NonDeterminism", Severity: "info"}}
+
+	cases := []struct {
+		failOn string
+		issues []detectors.Issue
+		want   int
+	}{
+		{"error", errs, 1},
+		{"error", warnings, 0},
+		{"error", nil, 0},
+		{"warning", errs, 1},
+		{"warning", warnings, 1},
+		{"warning", infos, 0},
+		{"info", infos, 1},
+		{"info", nil, 0},
+		{"never", errs, 0},
+	}
+	for _, c := range cases {
+		code, err := exitCode(c.issues, c.failOn, nil)
+		if err != nil || code != c.want {
+			t.Errorf("exitCode(%+v, %q) = %d, %v; want %d", c.issues, c.failOn, code, err, c.want)
+		}
+	}
+}
+
+func TestCountOutput(t *testing.T) {
+	issues := []detectors.Issue{
+		{Rule: "TimeUsage", Severity: "error"},
+		{Rule: "TimeUsage", Severity: "error"},
+		{Rule: "IOCalls", Severity: "warning"},
+	}
+
+	var out, breakdown strings.Builder
+	if err := writeCount(&out, &breakdown, issues); err != nil {
+		t.Fatalf("writeCount: %v", err)
+	}
+	if out.String() != "3\n" {
+		t.Fatalf("unexpected count output %q", out.String())
+	}
+	if breakdown.String() != "error=2 warning=1 info=0\n" {
+		t.Fatalf("unexpected breakdown %q", breakdown.String())
+	}
+
+	if code, _ := exitCode(issues, "error", nil); code != 1 {
+		t.Fatalf("expected exit code 1 with --fail-on error, got %d", code)
+	}
+	if code, _ := exitCode(nil, "info", nil); code != 0 {
+		t.Fatalf("expected exit code 0 without issues, got %d", code)
+	}
+}
+
+func TestFilterCategories(t *testing.T) {
+	issues := []detectors.Issue{
+		{Rule: "TimeUsage", Category: detectors.CategoryDeterminism},
+		{Rule: "Concurrency", Category: detectors.CategoryConcurrency},
+		{Rule: "IOCalls", Category: detectors.CategoryIO},
+		{Rule: "CustomRule"},
+	}
+
+	only := filterCategories(issues, "determinism", "")
+	if len(only) != 1 || only[0].Rule != "TimeUsage" {
+		t.Fatalf("expected only Determinism issues, got %+v", only)
+	}
+
+	rest := filterCategories(issues, "", "IO, Concurrency")
+	if len(rest) != 2 || rest[0].Rule != "TimeUsage" || rest[1].Rule != "CustomRule" {
+		t.Fatalf("expected IO and Concurrency excluded, got %+v", rest)
+	}
+
+	if all := filterCategories(issues, "", ""); len(all) != len(issues) {
+		t.Fatalf("expected no filtering without flags, got %+v", all)
+	}
+}
+
+func TestErrorsOnly(t *testing.T) {
+	issues := []detectors.Issue{
+		{Rule: "TimeUsage", Severity: "error"},
+		{Rule: "BusyWait", Severity: "warning"},
+		{Rule: "ClockAbstraction", Severity: "info"},
+		{Rule: "Concurrency", Severity: "error"},
+	}
+
+	sev, err := minSeverity("", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept := filterSeverity(issues, sev)
+	if len(kept) != 2 {
+		t.Fatalf("expected 2 error issues, got %+v", kept)
+	}
+	for _, is := range kept {
+		if is.Severity != "error" {
+			t.Errorf("non-error issue reported: %+v", is)
+		}
+	}
+	if code, _ := exitCode(kept, "error", nil); code != 1 {
+		t.Errorf("expected --errors-only to compose with --fail-on error, got exit %d", code)
+	}
+
+	if sev, err := minSeverity("error", true); err != nil || sev != "error" {
+		t.Errorf("expected an agreeing --min-severity to be accepted, got %q, %v", sev, err)
+	}
+	if _, err := minSeverity("warning", true); err == nil {
+		t.Error("expected --errors-only with --min-severity warning to be rejected")
+	}
+	if sev, _ := minSeverity("warning", false); len(filterSeverity(issues, sev)) != 3 {
+		t.Error("expected --min-severity warning to drop only info issues")
+	}
+}
+
+func TestReadTargetsFromFile(t *testing.T) {
+	list := filepath.Join(t.TempDir(), "targets.txt")
+	if err := os.WriteFile(list, []byte("svc/orders\n\n  svc/billing/workflow.go  \n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	targets, err := readTargets(list, nil)
+	if err != nil {
+		t.Fatalf("readTargets: %v", err)
+	}
+	if len(targets) != 2 || targets[0] != "svc/orders" || targets[1] != "svc/billing/workflow.go" {
+		t.Fatalf("unexpected targets %q", targets)
+	}
+
+	fromStdin, err := readTargets("-", strings.NewReader("a.go\nb.go\n"))
+	if err != nil || len(fromStdin) != 2 {
+		t.Fatalf("expected 2 targets from stdin, got %q (err=%v)", fromStdin, err)
+	}
+}
+
+func TestRunReporter(t *testing.T) {
+	issues := []detectors.Issue{{Rule: "TimeUsage", Severity: "error", Message: "Detected time.Now() in workflow."}}
+
+	var stdout, stderr strings.Builder
+	code, err := runReporter("cat; exit 3", nil, issues, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("reporter: %v", err)
+	}
+	if code != 3 {
+		t.Errorf("expected the reporter's exit status 3, got %d", code)
+	}
+	if !strings.Contains(stdout.String(), `"rule": "TimeUsage"`) {
+		t.Errorf("expected the JSON report on the reporter's stdin, got %q", stdout.String())
+	}
+
+	if code, err := runReporter("true", nil, issues, &stdout, &stderr); err != nil || code != 0 {
+		t.Errorf("expected a clean exit, got code=%d err=%v", code, err)
+	}
+	if _, err := runReporter("kill -9 $$", nil, issues, &stdout, &stderr); err == nil {
+		t.Error("expected an error for a killed reporter")
+	}
+}
+
+func TestResolveFormat(t *testing.T) {
+	cases := []struct{ format, output, want string }{
+		{"auto", "", "json"},
+		{"auto", "report.SARIF", "sarif"},
+		{"auto", "out/report.yml", "yaml"},
+		{"auto", "issues.ndjson", "jsonl"},
+		{"auto", "junit.xml", "junit"},
+		{"auto", "report.txt", "json"},
+		{"yaml", "report.json", "yaml"},
+	}
+	for _, c := range cases {
+		if got := resolveFormat(c.format, c.output); got != c.want {
+			t.Errorf("resolveFormat(%q, %q) = %q, want %q", c.format, c.output, got, c.want)
+		}
+	}
+}
+
+func TestWriteReportFileSARIF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.sarif")
+	issues := []detectors.Issue{{File: "wf.go", Line: 7, Column: 2, Rule: "TimeUsage", Severity: "error", Message: "Detected time.Now() in workflow."}}
+
+	if err := writeReportFile(path, resolveFormat("auto", path), nil, issues); err != nil {
+		t.Fatalf("write report: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+	for _, want := range []string{`"version": "2.1.0"`, `"ruleId": "TimeUsage"`, `"level": "error"`, `"uri": "wf.go"`, `"startLine": 7`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %s in SARIF report:\n%s", want, data)
+		}
+	}
+}
+
+func TestSubcommand(t *testing.T) {
+	cases := []struct {
+		args     []string
+		cmd      string
+		wantArgs []string
+	}{
+		{[]string{"lint", "--format", "yaml", "./..."}, "lint", []string{"--format", "yaml", "./..."}},
+		{[]string{"rules", "list"}, "rules", []string{"list"}},
+		{[]string{"merge", "a.json", "b.json"}, "merge", []string{"a.json", "b.json"}},
+		{[]string{"version"}, "version", []string{}},
+		{[]string{"--format", "json", "./wf"}, "lint", []string{"--format", "json", "./wf"}},
+		{[]string{"./wf"}, "lint", []string{"./wf"}},
+		{nil, "lint", nil},
+	}
+	for _, c := range cases {
+		cmd, args := subcommand(c.args)
+		if cmd != c.cmd || strings.Join(args, " ") != strings.Join(c.wantArgs, " ") {
+			t.Errorf("subcommand(%q) = %q %q, want %q %q", c.args, cmd, args, c.cmd, c.wantArgs)
+		}
+	}
+}
+
+func TestRunRules(t *testing.T) {
+	rules := []string{"--rules", "config/rules.yaml"}
+	cases := []struct {
+		args []string
+		code int
+		want string
+	}{
+		{append([]string{"validate"}, rules...), 0, "config/rules.yaml: ok"},
+		{append([]string{"list"}, rules...), 0, "\nMissingTimeout "},
+		{append([]string{"print"}, rules...), 0, "function_calls:"},
+		{[]string{"validate", "--rules", "/nonexistent/rules.yaml"}, 1, ""},
+		{[]string{"lint"}, 1, ""},
+		{nil, 1, ""},
+	}
+	for _, c := range cases {
+		var stdout, stderr strings.Builder
+		code := runRules(c.args, &stdout, &stderr)
+		if code != c.code || !strings.Contains(stdout.String(), c.want) {
+			t.Errorf("rules %q: code %d, stdout %q (stderr %q); want code %d containing %q", c.args, code, stdout.String(), stderr.String(), c.code, c.want)
+		}
+	}
+}
+
+func TestProjectConfigDiscovery(t *testing.T) {
+	root := t.TempDir()
+	cfg := "rules: lint/rules.yaml\nfail_on: warning\ncategory: [Determinism, IO]\nworkers: 2\nexclude: ['**/mocks/**', '*_gen.go']\ninclude_vendor: true\n"
+	if err := os.WriteFile(filepath.Join(root, ".cadence-lint.yaml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(root, "svc", "workflows")
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	pc, err := config.FindProjectConfig(target)
+	if err != nil || pc == nil {
+		t.Fatalf("expected the config at the root to be found, got %+v, %v", pc, err)
+	}
+
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	rulesPath := fs.String("rules", "config/rules.yaml", "")
+	failOn := fs.String("fail-on", "never", "")
+	category := fs.String("category", "", "")
+	workers := fs.Int("workers", 0, "")
+	for _, name := range []string{"format", "min-severity", "exclude-category"} {
+		fs.String(name, "", "")
+	}
+	fs.Bool("errors-only", false, "")
+	var excludes stringList
+	fs.Var(&excludes, "exclude", "")
+	includeVendor := fs.Bool("include-vendor", false, "")
+	if err := fs.Parse([]string{"--fail-on", "error", target}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := applyProjectConfig(fs, pc); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if want := filepath.Join(root, "lint", "rules.yaml"); *rulesPath != want {
+		t.Errorf("rules = %q, want %q (relative to the config file)", *rulesPath, want)
+	}
+	if *failOn != "error" {
+		t.Errorf("fail-on = %q, want the command-line value to win", *failOn)
+	}
+	if *category != "Determinism,IO" || *workers != 2 {
+		t.Errorf("category = %q, workers = %d; want the config values", *category, *workers)
+	}
+	if want := (stringList{"**/mocks/**", "*_gen.go"}); !reflect.DeepEqual(excludes, want) || !*includeVendor {
+		t.Errorf("exclude = %q, include-vendor = %v; want the config values", excludes, *includeVendor)
+	}
+
+	// An --exclude on the command line replaces the config's patterns.
+	fs = flag.NewFlagSet("lint", flag.ContinueOnError)
+	var cliExcludes stringList
+	fs.Var(&cliExcludes, "exclude", "")
+	for _, name := range []string{"rules", "format", "fail-on", "min-severity", "category", "exclude-category", "workers"} {
+		fs.String(name, "", "")
+	}
+	fs.Bool("errors-only", false, "")
+	fs.Bool("include-vendor", false, "")
+	if err := fs.Parse([]string{"--exclude", "legacy/**", target}); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyProjectConfig(fs, pc); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if want := (stringList{"legacy/**"}); !reflect.DeepEqual(cliExcludes, want) {
+		t.Errorf("exclude = %q, want only the command-line pattern", cliExcludes)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, ".cadence-lint.yaml"), []byte("fail-on: error\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.FindProjectConfig(target); err == nil {
+		t.Error("expected an unknown key to be rejected")
+	}
+}
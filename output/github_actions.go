@@ -0,0 +1,53 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+// ToGitHubActions renders issues as GitHub Actions workflow commands
+// (::error/::warning/::notice) so they show up as inline PR annotations.
+func ToGitHubActions(issues []detectors.Issue) string {
+	var b strings.Builder
+	for _, is := range issues {
+		fmt.Fprintf(&b, "::%s file=%s,line=%d,col=%d,title=%s::%s\n",
+			githubCommand(is.Severity),
+			escapeProperty(is.File),
+			is.Line,
+			is.Column,
+			escapeProperty(is.Rule),
+			escapeData(is.Message),
+		)
+	}
+	return b.String()
+}
+
+// githubCommand maps linter severities to GitHub annotation levels.
+func githubCommand(severity string) string {
+	switch severity {
+	case "error":
+		return "error"
+	case "warning":
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+// escapeData escapes a command message as required by the Actions runner.
+func escapeData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	s = strings.ReplaceAll(s, "\n", "%0A")
+	return s
+}
+
+// escapeProperty escapes a command property value; ':' and ',' are separators there.
+func escapeProperty(s string) string {
+	s = escapeData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	s = strings.ReplaceAll(s, ",", "%2C")
+	return s
+}
@@ -0,0 +1,31 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+func TestToGitHubActions(t *testing.T) {
+	issues := []detectors.Issue{
+		{File: "wf/order.go", Line: 12, Column: 5, Rule: "TimeUsage", Severity: "error", Message: "Detected time.Now() in workflow."},
+		{File: "wf/order.go", Line: 20, Column: 2, Rule: "IOCalls", Severity: "warning", Message: "100% bad\nsecond line"},
+		{File: "wf/order.go", Line: 30, Column: 1, Rule: "UnknownExternalCall", Severity: "info", Message: "verify"},
+	}
+
+	got := strings.Split(strings.TrimSuffix(ToGitHubActions(issues), "\n"), "\n")
+	want := []string{
+		"::error file=wf/order.go,line=12,col=5,title=TimeUsage::Detected time.Now() in workflow.",
+		"::warning file=wf/order.go,line=20,col=2,title=IOCalls::100%25 bad%0Asecond line",
+		"::notice file=wf/order.go,line=30,col=1,title=UnknownExternalCall::verify",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d commands, got %d:\n%s", len(want), len(got), strings.Join(got, "\n"))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d:\n got: %s\nwant: %s", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+// ToJSONL writes one compact JSON object per issue, newline-delimited, for
+// log pipelines and other streaming consumers.
+func ToJSONL(w io.Writer, issues []detectors.Issue) error {
+	enc := json.NewEncoder(w)
+	for _, is := range issues {
+		if err := enc.Encode(is); err != nil {
+			return err
+		}
+	}
+	return nil
+}
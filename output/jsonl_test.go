@@ -0,0 +1,36 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+func TestToJSONL(t *testing.T) {
+	issues := []detectors.Issue{
+		{File: "a.go", Line: 1, Column: 2, Rule: "TimeUsage", Severity: "error", Message: "multi\nline"},
+		{File: "b.go", Line: 3, Column: 4, Rule: "Concurrency", Severity: "error", Message: "goroutine", Func: "Wf"},
+	}
+
+	var buf bytes.Buffer
+	if err := ToJSONL(&buf, issues); err != nil {
+		t.Fatalf("ToJSONL: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(issues) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(issues), len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var got detectors.Issue
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v\n%s", i, err, line)
+		}
+		if got.File != issues[i].File || got.Message != issues[i].Message {
+			t.Errorf("line %d: got %+v, want %+v", i, got, issues[i])
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+const junitSuitesName = "cadence-workflow-linter"
+
+// The subset of the JUnit XML format that Jenkins and GitLab render.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Failure   junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ToJUnit renders issues as JUnit XML: one testsuite per file, in the order
+// files first appear, and one failed testcase per issue. Without issues it
+// returns a single empty testsuite, so CI still records a passing run.
+func ToJUnit(issues []detectors.Issue) ([]byte, error) {
+	root := junitTestSuites{Name: junitSuitesName, Tests: len(issues), Failures: len(issues)}
+	index := map[string]int{}
+	for _, is := range issues {
+		i, ok := index[is.File]
+		if !ok {
+			i = len(root.Suites)
+			index[is.File] = i
+			root.Suites = append(root.Suites, junitTestSuite{Name: is.File})
+		}
+		suite := &root.Suites[i]
+		suite.Tests++
+		suite.Failures++
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      fmt.Sprintf("%s at %d:%d", is.Rule, is.Line, is.Column),
+			ClassName: is.File,
+			Failure: junitFailure{
+				Message: is.Message,
+				Type:    is.Rule,
+				Text:    fmt.Sprintf("%s:%d:%d: [%s] %s: %s", is.File, is.Line, is.Column, is.Severity, is.Rule, is.Message),
+			},
+		})
+	}
+	if len(root.Suites) == 0 {
+		root.Suites = []junitTestSuite{{Name: junitSuitesName}}
+	}
+
+	out, err := xml.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
@@ -0,0 +1,54 @@
+package output
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+func TestToJUnit(t *testing.T) {
+	issues := []detectors.Issue{
+		{File: "a.go", Line: 1, Column: 2, Rule: "TimeUsage", Severity: "error", Message: "now"},
+		{File: "b.go", Line: 3, Column: 4, Rule: "Concurrency", Severity: "info", Message: "goroutine"},
+		{File: "b.go", Line: 5, Column: 1, Rule: "TimeUsage", Severity: "warning", Message: "sleep"},
+	}
+
+	out, err := ToJUnit(issues)
+	if err != nil {
+		t.Fatalf("ToJUnit: %v", err)
+	}
+	var got junitTestSuites
+	if err := xml.Unmarshal(out, &got); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, out)
+	}
+	if got.Tests != 3 || got.Failures != 3 || len(got.Suites) != 2 {
+		t.Fatalf("unexpected testsuites: %+v", got)
+	}
+	for i, want := range []struct {
+		file     string
+		failures int
+	}{{"a.go", 1}, {"b.go", 2}} {
+		s := got.Suites[i]
+		if s.Name != want.file || s.Tests != want.failures || s.Failures != want.failures || len(s.Cases) != want.failures {
+			t.Errorf("suite %d: got %+v, want %s with %d failures", i, s, want.file, want.failures)
+		}
+	}
+	if f := got.Suites[1].Cases[0].Failure; f.Type != "Concurrency" || f.Message != "goroutine" {
+		t.Errorf("unexpected failure: %+v", f)
+	}
+}
+
+func TestToJUnitEmpty(t *testing.T) {
+	out, err := ToJUnit(nil)
+	if err != nil {
+		t.Fatalf("ToJUnit: %v", err)
+	}
+	var got junitTestSuites
+	if err := xml.Unmarshal(out, &got); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, out)
+	}
+	if got.Tests != 0 || len(got.Suites) != 1 || got.Suites[0].Tests != 0 || len(got.Suites[0].Cases) != 0 {
+		t.Fatalf("expected one empty testsuite, got %+v", got)
+	}
+}
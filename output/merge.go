@@ -0,0 +1,69 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+// ReadJSONReport parses a json report as the linter writes it: either the
+// bare issue array or the metadata envelope.
+func ReadJSONReport(data []byte) ([]detectors.Issue, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		var r Report
+		if err := json.Unmarshal(data, &r); err != nil {
+			return nil, err
+		}
+		return r.Issues, nil
+	}
+	var issues []detectors.Issue
+	if err := json.Unmarshal(data, &issues); err != nil {
+		return nil, fmt.Errorf("not a json report: %w", err)
+	}
+	return issues, nil
+}
+
+// MergeIssues combines the issues of several reports, e.g. from sharded CI
+// runs. Issues at the same position with the same rule and message are kept
+// once, and the result is ordered by file, line, column, rule and message,
+// like a single run's report.
+func MergeIssues(reports ...[]detectors.Issue) []detectors.Issue {
+	type key struct {
+		file         string
+		line, column int
+		rule, msg    string
+	}
+	seen := map[key]bool{}
+	merged := []detectors.Issue{}
+	for _, issues := range reports {
+		for _, is := range issues {
+			k := key{is.File, is.Line, is.Column, is.Rule, is.Message}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			merged = append(merged, is)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Message < b.Message
+	})
+	return merged
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+func TestMergeIssues(t *testing.T) {
+	shard1, err := ReadJSONReport([]byte(`[
+  {"file": "b.go", "line": 3, "column": 1, "rule": "TimeUsage", "severity": "error", "message": "now"},
+  {"file": "a.go", "line": 9, "column": 2, "rule": "Concurrency", "severity": "info", "message": "goroutine"}
+]`))
+	if err != nil {
+		t.Fatalf("read shard 1: %v", err)
+	}
+	// The second shard uses the metadata envelope and overlaps the first on b.go:3.
+	shard2, err := ReadJSONReport([]byte(`{"metadata": {}, "issues": [
+  {"file": "b.go", "line": 3, "column": 1, "rule": "TimeUsage", "severity": "error", "message": "now"},
+  {"file": "a.go", "line": 1, "column": 5, "rule": "Randomness", "severity": "warning", "message": "rand"}
+]}`))
+	if err != nil {
+		t.Fatalf("read shard 2: %v", err)
+	}
+
+	got := MergeIssues(shard1, shard2)
+	want := []detectors.Issue{
+		{File: "a.go", Line: 1, Column: 5, Rule: "Randomness", Severity: "warning", Message: "rand"},
+		{File: "a.go", Line: 9, Column: 2, Rule: "Concurrency", Severity: "info", Message: "goroutine"},
+		{File: "b.go", Line: 3, Column: 1, Rule: "TimeUsage", Severity: "error", Message: "now"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestReadJSONReportRejectsOtherInput(t *testing.T) {
+	if _, err := ReadJSONReport([]byte("issues: []\n")); err == nil {
+		t.Fatal("expected an error for a yaml report")
+	}
+}
@@ -0,0 +1,18 @@
+package output
+
+import (
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+	"github.com/afony10/cadence-workflow-linter/vcs"
+)
+
+// Report is the json/yaml report envelope, used when metadata is requested.
+// Without metadata the report is the bare issue array.
+type Report struct {
+	Metadata *Metadata         `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Issues   []detectors.Issue `json:"issues" yaml:"issues"`
+}
+
+// Metadata ties a report to where it was produced.
+type Metadata struct {
+	Git *vcs.Revision `json:"git,omitempty" yaml:"git,omitempty"` // absent outside a git repository
+}
@@ -0,0 +1,61 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+// RuleStat summarizes how often a rule fired and across how many files.
+type RuleStat struct {
+	Rule  string `json:"rule" yaml:"rule"`
+	Count int    `json:"count" yaml:"count"`
+	Files int    `json:"files" yaml:"files"`
+}
+
+// RuleStats aggregates issues per rule, sorted by count then file spread
+// (descending). Rules in knownRules that never fired are included with zero
+// counts so unused rules stand out when tuning a ruleset.
+func RuleStats(issues []detectors.Issue, knownRules []string) []RuleStat {
+	counts := map[string]int{}
+	files := map[string]map[string]bool{}
+	for _, r := range knownRules {
+		counts[r] += 0
+		files[r] = map[string]bool{}
+	}
+	for _, is := range issues {
+		counts[is.Rule]++
+		if files[is.Rule] == nil {
+			files[is.Rule] = map[string]bool{}
+		}
+		files[is.Rule][is.File] = true
+	}
+
+	stats := make([]RuleStat, 0, len(counts))
+	for rule, n := range counts {
+		stats = append(stats, RuleStat{Rule: rule, Count: n, Files: len(files[rule])})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		if stats[i].Files != stats[j].Files {
+			return stats[i].Files > stats[j].Files
+		}
+		return stats[i].Rule < stats[j].Rule
+	})
+	return stats
+}
+
+// WriteRuleStats prints rule statistics as an aligned table.
+func WriteRuleStats(w io.Writer, stats []RuleStat) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RULE\tCOUNT\tFILES")
+	for _, s := range stats {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", s.Rule, s.Count, s.Files)
+	}
+	return tw.Flush()
+}
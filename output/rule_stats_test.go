@@ -0,0 +1,52 @@
+package output
+
+import (
+	"bytes"
+	"go/ast"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer"
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+	"github.com/afony10/cadence-workflow-linter/analyzer/modutils"
+	"github.com/afony10/cadence-workflow-linter/config"
+)
+
+func TestRuleStatsForTestdataScan(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+	factory := func(moduleInfo *modutils.ModuleInfo) []ast.Visitor {
+		return []ast.Visitor{
+			detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, moduleInfo),
+			detectors.NewGoroutineDetector(),
+			detectors.NewChannelDetector(),
+		}
+	}
+
+	issues, err := analyzer.ScanFile("../testdata/workflow_violation.go", factory)
+	if err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+
+	stats := RuleStats(issues, []string{"Network"})
+	want := []RuleStat{
+		{Rule: "Concurrency", Count: 2, Files: 1},
+		{Rule: "IOCalls", Count: 1, Files: 1},
+		{Rule: "TimeUsage", Count: 1, Files: 1},
+		{Rule: "Network", Count: 0, Files: 0},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Fatalf("unexpected stats:\n got: %+v\nwant: %+v", stats, want)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRuleStats(&buf, stats); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "RULE") || !strings.Contains(buf.String(), "Concurrency  2      1") {
+		t.Fatalf("unexpected table:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,44 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+func TestToSARIF(t *testing.T) {
+	issues := []detectors.Issue{
+		{File: "a.go", Line: 1, Column: 2, Rule: "TimeUsage", Severity: "error", Message: "now"},
+		{File: "b.go", Line: 3, Column: 4, Rule: "Concurrency", Severity: "info", Message: "goroutine"},
+		{File: "b.go", Line: 5, Column: 1, Rule: "TimeUsage", Severity: "warning", Message: "sleep"},
+	}
+
+	var buf bytes.Buffer
+	if err := ToSARIF(&buf, issues); err != nil {
+		t.Fatalf("ToSARIF: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log: %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[0].ID != "Concurrency" {
+		t.Errorf("expected the two rules sorted by ID, got %+v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("expected 3 results, got %+v", run.Results)
+	}
+	for i, want := range []string{"error", "note", "warning"} {
+		if run.Results[i].Level != want {
+			t.Errorf("result %d: level %q, want %q", i, run.Results[i].Level, want)
+		}
+	}
+	if loc := run.Results[1].Locations[0].PhysicalLocation; loc.ArtifactLocation.URI != "b.go" || loc.Region.StartLine != 3 {
+		t.Errorf("unexpected location: %+v", loc)
+	}
+}
@@ -0,0 +1,96 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+)
+
+// schemaEnums restricts string fields, keyed by "Type.jsonName", to known values.
+var schemaEnums = map[string][]string{
+	"Issue.severity":          {"error", "warning", "info"},
+	"SuggestedFix.confidence": {detectors.FixConfidenceHigh, detectors.FixConfidenceLow},
+}
+
+// Schema returns a JSON Schema for the json report: an array of issues, or a
+// Report envelope when metadata was requested. Each jsonl line is one issue.
+// The schema is generated from the Go types, so it can't drift from what the
+// linter emits.
+func Schema() map[string]any {
+	defs := map[string]any{}
+	schemaDef(reflect.TypeOf(Report{}), defs)
+	return map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   "cadence-workflow-linter report",
+		"oneOf": []any{
+			map[string]any{"type": "array", "items": map[string]any{"$ref": "#/$defs/Issue"}},
+			map[string]any{"$ref": "#/$defs/Report"},
+		},
+		"$defs": defs,
+	}
+}
+
+// WriteSchema writes Schema as indented JSON.
+func WriteSchema(w io.Writer) error {
+	out, err := json.MarshalIndent(Schema(), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(out, '\n'))
+	return err
+}
+
+// schemaDef adds struct type t (and the structs it refers to) to defs.
+func schemaDef(t reflect.Type, defs map[string]any) {
+	if _, ok := defs[t.Name()]; ok {
+		return
+	}
+	props := map[string]any{}
+	required := []string{}
+	def := map[string]any{"type": "object", "properties": props, "additionalProperties": false}
+	defs[t.Name()] = def
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		prop := schemaType(f.Type, defs)
+		if enum, ok := schemaEnums[t.Name()+"."+name]; ok {
+			prop["enum"] = enum
+		}
+		props[name] = prop
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	def["required"] = required
+}
+
+func schemaType(t reflect.Type, defs map[string]any) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaType(t.Elem(), defs)
+	case reflect.Struct:
+		schemaDef(t, defs)
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaType(t.Elem(), defs)}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	}
+	return map[string]any{}
+}
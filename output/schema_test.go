@@ -0,0 +1,144 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/afony10/cadence-workflow-linter/config"
+	"github.com/afony10/cadence-workflow-linter/linter"
+	"github.com/afony10/cadence-workflow-linter/vcs"
+)
+
+func TestReportValidatesAgainstSchema(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+	res, err := linter.Lint("../testdata", linter.Options{Rules: rules})
+	if err != nil {
+		t.Fatalf("lint: %v", err)
+	}
+	report, err := json.Marshal(res.Issues)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !bytes.Contains(report, []byte(`"suggested_fix"`)) || !bytes.Contains(report, []byte(`"callstack"`)) {
+		t.Fatal("expected the testdata report to exercise suggested fixes and call stacks")
+	}
+
+	// Round-trip the schema through JSON, as a consumer would see it.
+	var buf bytes.Buffer
+	if err := WriteSchema(&buf); err != nil {
+		t.Fatalf("write schema: %v", err)
+	}
+	var schema, doc any
+	if err := json.Unmarshal(buf.Bytes(), &schema); err != nil {
+		t.Fatalf("schema is not JSON: %v", err)
+	}
+	if err := json.Unmarshal(report, &doc); err != nil {
+		t.Fatalf("report is not JSON: %v", err)
+	}
+	root := schema.(map[string]any)
+	if err := validate(root, root["$defs"].(map[string]any), doc, "$"); err != nil {
+		t.Fatal(err)
+	}
+
+	enveloped, err := json.Marshal(Report{
+		Metadata: &Metadata{Git: &vcs.Revision{Commit: "3f2a9c1d0b7e", Branch: "main"}},
+		Issues:   res.Issues,
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var env any
+	if err := json.Unmarshal(enveloped, &env); err != nil {
+		t.Fatalf("envelope is not JSON: %v", err)
+	}
+	if err := validate(root, root["$defs"].(map[string]any), env, "$"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A report with an unknown field or a bad severity must not validate.
+	for _, bad := range []string{
+		`[{"file":"a.go","line":1,"column":1,"rule":"R","severity":"fatal","message":"m"}]`,
+		`[{"file":"a.go","line":1,"column":1,"rule":"R","severity":"info","message":"m","extra":true}]`,
+		`[{"file":"a.go","line":1,"column":1,"rule":"R","severity":"info"}]`,
+	} {
+		var v any
+		_ = json.Unmarshal([]byte(bad), &v)
+		if err := validate(root, root["$defs"].(map[string]any), v, "$"); err == nil {
+			t.Errorf("expected %s to fail validation", bad)
+		}
+	}
+}
+
+// validate checks v against the subset of JSON Schema that Schema emits.
+func validate(schema map[string]any, defs map[string]any, v any, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		return validate(defs[ref[len("#/$defs/"):]].(map[string]any), defs, v, path)
+	}
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		matched := 0
+		for _, alt := range oneOf {
+			if validate(alt.(map[string]any), defs, v, path) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return fmt.Errorf("%s: matches %d of the oneOf alternatives, want 1", path, matched)
+		}
+		return nil
+	}
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			found = found || e == v
+		}
+		if !found {
+			return fmt.Errorf("%s: %v not in %v", path, v, enum)
+		}
+	}
+	switch schema["type"] {
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("%s: want array, got %T", path, v)
+		}
+		for i, el := range arr {
+			if err := validate(schema["items"].(map[string]any), defs, el, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: want object, got %T", path, v)
+		}
+		props := schema["properties"].(map[string]any)
+		for _, r := range schema["required"].([]any) {
+			if _, ok := obj[r.(string)]; !ok {
+				return fmt.Errorf("%s: missing required %q", path, r)
+			}
+		}
+		for k, val := range obj {
+			p, ok := props[k]
+			if !ok {
+				return fmt.Errorf("%s: unexpected property %q", path, k)
+			}
+			if err := validate(p.(map[string]any), defs, val, path+"."+k); err != nil {
+				return err
+			}
+		}
+	case "string":
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("%s: want string, got %T", path, v)
+		}
+	case "integer":
+		if f, ok := v.(float64); !ok || f != float64(int64(f)) {
+			return fmt.Errorf("%s: want integer, got %v", path, v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+
+	"github.com/afony10/cadence-workflow-linter/config"
+)
+
+// applyProjectConfig sets the lint flags pc has values for, unless they were
+// given on the command line: flags > project config > flag defaults.
+func applyProjectConfig(fs *flag.FlagSet, pc *config.ProjectConfig) error {
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	values := map[string]string{
+		"rules":            pc.Rules,
+		"format":           pc.Format,
+		"fail-on":          pc.FailOn,
+		"min-severity":     pc.MinSeverity,
+		"category":         strings.Join(pc.Category, ","),
+		"exclude-category": strings.Join(pc.ExcludeCategory, ","),
+	}
+	if explicit["errors-only"] {
+		delete(values, "min-severity") // --errors-only is a command-line min severity
+	}
+	if pc.Workers != 0 {
+		values["workers"] = strconv.Itoa(pc.Workers)
+	}
+	if pc.IncludeVendor {
+		values["include-vendor"] = "true"
+	}
+	for name, value := range values {
+		if value == "" || explicit[name] {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return err
+		}
+	}
+	// --exclude is repeatable; patterns given on the command line replace
+	// the config's rather than adding to them.
+	if !explicit["exclude"] {
+		for _, pattern := range pc.Exclude {
+			if err := fs.Set("exclude", pattern); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
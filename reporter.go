@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/afony10/cadence-workflow-linter/analyzer/detectors"
+	"github.com/afony10/cadence-workflow-linter/output"
+)
+
+// runReporter runs command through sh with the JSON report on its stdin and
+// its output connected to stdout and stderr. It returns the command's exit
+// status, which the linter forwards; err is only set when the command
+// couldn't be run or was killed.
+func runReporter(command string, meta *output.Metadata, issues []detectors.Issue, stdout, stderr io.Writer) (int, error) {
+	var report bytes.Buffer
+	if err := writeReport(&report, "json", meta, issues); err != nil {
+		return 0, err
+	}
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdin = &report
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() >= 0 {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reporter %q: %w", command, err)
+	}
+	return 0, nil
+}
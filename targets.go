@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// readTargets reads newline-separated paths to scan from path, or from stdin
+// when path is "-". Blank lines are ignored.
+func readTargets(path string, stdin io.Reader) ([]string, error) {
+	r := stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	var targets []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if t := strings.TrimSpace(sc.Text()); t != "" {
+			targets = append(targets, t)
+		}
+	}
+	return targets, sc.Err()
+}
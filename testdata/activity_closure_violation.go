@@ -0,0 +1,17 @@
+package testdata
+
+import (
+	"go.uber.org/cadence/workflow"
+)
+
+func formatReceipt(id string) string { return "receipt-" + id }
+
+func ClosureArgWorkflow(ctx workflow.Context, onDone func(string)) error {
+	_ = workflow.ExecuteActivity(ctx, SendReceipt, func(id string) string { return id }) // should be flagged
+	format := func(id string) string { return "#" + id }
+	_ = workflow.ExecuteActivity(ctx, SendReceipt, format)        // should be flagged
+	_ = workflow.ExecuteActivity(ctx, SendReceipt, onDone)        // should be flagged
+	_ = workflow.ExecuteActivity(ctx, SendReceipt, formatReceipt) // should be flagged
+	_ = workflow.ExecuteActivity(ctx, SendReceipt, "order-42")    // should NOT be flagged
+	return nil
+}
@@ -0,0 +1,22 @@
+package testdata
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func ShipOrderWorkflow(ctx workflow.Context) error {
+	ao := workflow.ActivityOptions{ // should be flagged
+		TaskList:               "shipping",
+		ScheduleToStartTimeout: time.Minute,
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	ok := workflow.ActivityOptions{ // should NOT be flagged
+		ScheduleToStartTimeout: time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+	}
+	ctx = workflow.WithActivityOptions(ctx, ok)
+	return workflow.ExecuteActivity(ctx, "Ship").Get(ctx, nil)
+}
@@ -0,0 +1,12 @@
+package buildconstraints
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func WindowsWorkflow(ctx workflow.Context) error {
+	_ = time.Now() // should be flagged only when linting for GOOS=windows
+	return nil
+}
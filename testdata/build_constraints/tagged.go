@@ -0,0 +1,14 @@
+//go:build windows && amd64
+
+package buildconstraints
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func TaggedWorkflow(ctx workflow.Context) error {
+	_ = time.Now() // should be flagged only when linting for windows/amd64
+	return nil
+}
@@ -0,0 +1,9 @@
+package buildconstraints
+
+import (
+	"go.uber.org/cadence/workflow"
+)
+
+func PortableWorkflow(ctx workflow.Context) error {
+	return workflow.Sleep(ctx, 0)
+}
@@ -0,0 +1,25 @@
+package testdata
+
+import (
+	"runtime"
+	rdebug "runtime/debug"
+	"strings"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func BuildAwareWorkflow(ctx workflow.Context) error {
+	batch := 10
+	if strings.HasPrefix(runtime.Version(), "go1.2") { // should be flagged
+		batch = 20
+	}
+	if info, ok := rdebug.ReadBuildInfo(); ok && info.Main.Version != "" { // should be flagged
+		batch++
+	}
+	_ = batch
+	return nil
+}
+
+func BuildInfoActivity() string {
+	return runtime.Version() // should NOT be flagged
+}
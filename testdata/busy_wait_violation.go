@@ -0,0 +1,27 @@
+package testdata
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func DeadlineSpinWorkflow(ctx workflow.Context, deadline time.Time) error {
+	for workflow.Now(ctx).Before(deadline) { // should be flagged
+	}
+	return nil
+}
+
+func DeadlineSleepWorkflow(ctx workflow.Context, deadline time.Time) error {
+	for workflow.Now(ctx).Before(deadline) { // should NOT be flagged
+		_ = workflow.Sleep(ctx, time.Minute)
+	}
+	return nil
+}
+
+func DeadlineTimerWorkflow(ctx workflow.Context, deadline time.Time) error {
+	for !workflow.Now(ctx).After(deadline) { // should NOT be flagged
+		_ = workflow.NewTimer(ctx, deadline.Sub(workflow.Now(ctx))).Get(ctx, nil)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package testdata
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+var defaultClock Clock = systemClock{}
+
+type scheduler struct {
+	clock Clock
+}
+
+func ClockWorkflow(ctx workflow.Context) error {
+	_ = defaultClock.Now() // should be flagged (info)
+	s := scheduler{clock: defaultClock}
+	_ = s.clock.Now()     // should be flagged (info)
+	_ = workflow.Now(ctx) // should NOT be flagged
+	return nil
+}
+
+func ClockActivity() time.Time {
+	return defaultClock.Now() // should NOT be flagged
+}
@@ -0,0 +1,35 @@
+package testdata
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func closureAuditStamp() int64 {
+	return time.Now().Unix() // should NOT be flagged (only called from a local activity)
+}
+
+func ClosureWorkflow(ctx workflow.Context) error {
+	func() {
+		_ = time.Now() // should be flagged (immediately invoked)
+	}()
+
+	stamp := func() time.Time {
+		return time.Now() // should be flagged (called through a variable)
+	}
+	_ = stamp()
+
+	audit := func(c context.Context) {
+		_ = time.Now() // should be flagged (takes a context.Context but isn't an activity)
+	}
+	audit(nil)
+
+	lctx := workflow.WithLocalActivityOptions(ctx, workflow.LocalActivityOptions{ScheduleToCloseTimeout: time.Second})
+	var unix int64
+	return workflow.ExecuteLocalActivity(lctx, func(ctx context.Context) (int64, error) {
+		_ = time.Now()                  // should NOT be flagged (local activity body)
+		return closureAuditStamp(), nil // should NOT be flagged
+	}).Get(lctx, &unix)
+}
@@ -0,0 +1,25 @@
+package testdata
+
+import (
+	"context"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func MovedCodeWorkflow(ctx workflow.Context) error {
+	bg := context.Background() // should be flagged
+	_ = bg
+	return lookupCustomer()
+}
+
+func lookupCustomer() error {
+	_ = context.TODO() // should be flagged (reachable from MovedCodeWorkflow)
+	return nil
+}
+
+func LookupCustomerActivity(ctx context.Context) error {
+	cctx, cancel := context.WithCancel(context.Background()) // should NOT be flagged (activity)
+	defer cancel()
+	_ = cctx
+	return nil
+}
@@ -0,0 +1,34 @@
+package testdata
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func BoundedChargeWorkflow(ctx workflow.Context, parent context.Context) error {
+	tctx, cancel := context.WithTimeout(parent, 30*time.Second) // should be flagged
+	defer cancel()
+	if err := ChargeCardActivity(tctx, "order-1"); err != nil {
+		return err
+	}
+
+	dctx, cancel2 := context.WithDeadline(parent, time.Unix(0, 0)) // should be flagged
+	defer cancel2()
+	return workflow.ExecuteActivity(dctx, "ChargeCard", "order-2").Get(ctx, nil)
+}
+
+func ChargeCardActivity(ctx context.Context, orderID string) error {
+	tctx, cancel := context.WithTimeout(ctx, time.Second) // should NOT be flagged (activity)
+	defer cancel()
+	_ = tctx
+	return nil
+}
+
+func LoggingWorkflow(ctx workflow.Context, parent context.Context) error {
+	lctx, cancel := context.WithTimeout(parent, time.Second) // should NOT be flagged (never bounds an activity)
+	defer cancel()
+	_ = lctx
+	return nil
+}
@@ -0,0 +1,23 @@
+package testdata
+
+import (
+	"os"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func DeferLoopWorkflow(ctx workflow.Context, paths []string) error {
+	for _, p := range paths {
+		defer os.Remove(p) // should be flagged (info, naming the IOCalls rule for os.Remove)
+		for i := 0; i < 2; i++ {
+			defer release(i) // should be flagged once (info)
+		}
+		func() {
+			defer release(0) // should NOT be flagged: runs when the closure returns
+		}()
+	}
+	defer release(1) // should NOT be flagged: not inside a loop
+	return nil
+}
+
+func release(int) {}
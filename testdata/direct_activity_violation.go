@@ -0,0 +1,20 @@
+package testdata
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func PaymentWorkflow(ctx workflow.Context, order string) error {
+	if err := ValidatePaymentActivity(nil, order); err != nil { // should be flagged
+		return err
+	}
+	actx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{StartToCloseTimeout: time.Minute})
+	return workflow.ExecuteActivity(actx, ValidatePaymentActivity, order).Get(ctx, nil) // should NOT be flagged
+}
+
+func ValidatePaymentActivity(ctx context.Context, order string) error {
+	return nil
+}
@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	"context"
+	"embed"
+
+	"go.uber.org/cadence/workflow"
+)
+
+//go:embed testdata
+var emailTemplates embed.FS
+
+func EmailWorkflow(ctx workflow.Context) error {
+	_, err := emailTemplates.ReadFile("testdata/welcome.txt") // should be flagged
+	if err != nil {
+		return err
+	}
+	_, err = templates.ReadDir("testdata") // should be flagged (declared in fs_violation.go)
+	return err
+}
+
+func EmailActivity(ctx context.Context) ([]byte, error) {
+	return emailTemplates.ReadFile("testdata/welcome.txt") // should NOT be flagged
+}
@@ -0,0 +1,27 @@
+package testdata
+
+import (
+	"context"
+	"os"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func RegionalWorkflow(ctx workflow.Context) error {
+	region := os.Getenv("FOO") // should be flagged
+	if region == "" {
+		region = defaultRegion()
+	}
+	return workflow.ExecuteActivity(ctx, "Deploy", region).Get(ctx, nil)
+}
+
+func defaultRegion() string {
+	if r, ok := os.LookupEnv("DEFAULT_REGION"); ok { // should be flagged (reachable from RegionalWorkflow)
+		return r
+	}
+	return "us-east-1"
+}
+
+func RegionalActivity(ctx context.Context) (string, error) {
+	return os.Getenv("FOO"), nil // should NOT be flagged
+}
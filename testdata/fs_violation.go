@@ -0,0 +1,26 @@
+package testdata
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+	"os"
+
+	"go.uber.org/cadence/workflow"
+)
+
+//go:embed testdata
+var templates embed.FS
+
+func TemplateWorkflow(ctx workflow.Context) error {
+	_, err := fs.ReadFile(templates, "testdata/welcome.txt") // should be flagged
+	if err != nil {
+		return err
+	}
+	_, err = fs.ReadDir(os.DirFS("/etc"), ".") // should be flagged (twice)
+	return err
+}
+
+func TemplateActivity(ctx context.Context) ([]byte, error) {
+	return fs.ReadFile(templates, "testdata/welcome.txt") // should NOT be flagged
+}
@@ -0,0 +1,4 @@
+package testdata
+
+// crossFileProcessed is mutated by TallyWorkflow in global_cross_file_workflow.go.
+var crossFileProcessed int
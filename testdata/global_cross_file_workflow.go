@@ -0,0 +1,12 @@
+package testdata
+
+import (
+	"go.uber.org/cadence/workflow"
+)
+
+func TallyWorkflow(ctx workflow.Context, items []string) error {
+	for range items {
+		crossFileProcessed++ // should be flagged (declared in global_cross_file_vars.go)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package testdata
+
+import (
+	"go.uber.org/cadence/workflow"
+)
+
+var processedCount int
+
+var itemTotals = map[string]int{}
+
+func CountingWorkflow(ctx workflow.Context, items []string) error {
+	for i := range items {
+		processedCount++            // should be flagged
+		itemTotals[items[i]] += 1   // should be flagged
+		local := processedCount * 2 // should NOT be flagged (read only)
+		_ = local
+	}
+	processedCount = 0 // should NOT be flagged (not in a loop)
+	return nil
+}
+
+func ShadowingWorkflow(ctx workflow.Context, items []string) error {
+	processedCount := 0
+	for range items {
+		processedCount++ // should NOT be flagged (local shadows the global)
+	}
+	_ = processedCount
+	return nil
+}
+
+func CountingHelper(items []string) {
+	for range items {
+		processedCount++ // should NOT be flagged (not reachable from a workflow)
+	}
+}
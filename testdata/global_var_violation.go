@@ -0,0 +1,32 @@
+package testdata
+
+import (
+	"go.uber.org/cadence/workflow"
+)
+
+var counter int
+
+const maxAttempts = 3
+
+var retryLabel = "default"
+
+type attemptOptions struct {
+	retryLabel string
+}
+
+func AttemptWorkflow(ctx workflow.Context) error {
+	counter = counter + 1 // should be flagged (write and read)
+	attempts := 0         // should NOT be flagged (local)
+	for attempts < maxAttempts {
+		attempts++ // should NOT be flagged (local; maxAttempts is a constant)
+	}
+	opts := attemptOptions{retryLabel: "local"} // should NOT be flagged (struct field key)
+	_ = opts
+	labels := map[string]int{retryLabel: attempts} // should be flagged (map key reads the var)
+	_ = labels
+	return nil
+}
+
+func counterHelper() int {
+	return counter // should NOT be flagged (not reachable from a workflow)
+}
@@ -0,0 +1,43 @@
+package testdata
+
+import (
+	"context"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func MigratedPumpWorkflow(ctx workflow.Context) error {
+	results := make(chan string) // should be flagged (native channel)
+	done := make(chan struct{})  // should be flagged (native channel)
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		select { // should be flagged (native select inside workflow.Go)
+		case r := <-results:
+			_ = r
+		case <-done:
+		}
+	})
+	return nil
+}
+
+func startPump(ctx workflow.Context, in chan int) {
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		select { // should be flagged (helper reached from the workflow)
+		case v := <-in:
+			_ = v
+		default:
+		}
+	})
+}
+
+func HelperPumpWorkflow(ctx workflow.Context) error {
+	startPump(ctx, nil)
+	return nil
+}
+
+func PumpActivity(ctx context.Context, in chan int) {
+	go func() {
+		select { // should NOT be flagged (activity)
+		case <-in:
+		}
+	}()
+}
@@ -0,0 +1,23 @@
+package testdata
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+
+	"go.uber.org/cadence/workflow"
+)
+
+type gobPayload struct{ ID string }
+
+func GobWorkflow(ctx workflow.Context, p gobPayload) error {
+	gob.Register(gobPayload{}) // should be flagged
+	var buf bytes.Buffer
+	return gob.NewEncoder(&buf).Encode(p) // should be flagged
+}
+
+func GobActivity(ctx context.Context, data []byte) (gobPayload, error) {
+	var p gobPayload
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&p) // should NOT be flagged
+	return p, err
+}
@@ -0,0 +1,20 @@
+package testdata
+
+import (
+	"go.uber.org/cadence/workflow"
+)
+
+func FanOutWorkflow(ctx workflow.Context, ids []string) error {
+	for _, id := range ids {
+		go func(c workflow.Context) { // should be flagged (context passed as parameter)
+			_ = workflow.ExecuteActivity(c, "Process", id)
+		}(ctx)
+	}
+	go func() { // should be flagged (context captured by the closure)
+		workflow.GetLogger(ctx).Info("background")
+	}()
+	go func() { // should be flagged (plain goroutine)
+		println("no context")
+	}()
+	return nil
+}
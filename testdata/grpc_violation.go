@@ -0,0 +1,31 @@
+package testdata
+
+import (
+	"context"
+	"net/rpc"
+
+	"go.uber.org/cadence/workflow"
+	"google.golang.org/grpc"
+)
+
+func InventoryWorkflow(ctx workflow.Context) error {
+	conn, err := grpc.Dial("inventory:443") // should be flagged
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client, err := rpc.Dial("tcp", "legacy:1234") // should be flagged
+	if err != nil {
+		return err
+	}
+	return client.Close()
+}
+
+func InventoryActivity(ctx context.Context) error {
+	conn, err := grpc.NewClient("inventory:443") // should NOT be flagged
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
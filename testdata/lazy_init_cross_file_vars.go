@@ -0,0 +1,6 @@
+package testdata
+
+import "sync"
+
+// crossFileConfigOnce is used by ConfigWorkflow in lazy_init_cross_file_workflow.go.
+var crossFileConfigOnce sync.Once
@@ -0,0 +1,10 @@
+package testdata
+
+import (
+	"go.uber.org/cadence/workflow"
+)
+
+func ConfigWorkflow(ctx workflow.Context) error {
+	crossFileConfigOnce.Do(loadRates) // should be flagged (declared in lazy_init_cross_file_vars.go)
+	return nil
+}
@@ -0,0 +1,33 @@
+package testdata
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/cadence/workflow"
+)
+
+var (
+	ratesOnce sync.Once
+	rates     map[string]float64
+)
+
+func loadRates() {
+	rates = map[string]float64{"EUR": 1.1}
+}
+
+func ConvertWorkflow(ctx workflow.Context, currency string) (float64, error) {
+	ratesOnce.Do(loadRates) // should be flagged (LazyInit)
+	return rates[currency], nil
+}
+
+func ConvertLocalOnceWorkflow(ctx workflow.Context) error {
+	var once sync.Once // flagged as a sync.Once variable (Concurrency)
+	once.Do(loadRates) // flagged as Concurrency, not LazyInit
+	return nil
+}
+
+func ConvertActivity(ctx context.Context, currency string) (float64, error) {
+	ratesOnce.Do(loadRates) // should NOT be flagged (activity)
+	return rates[currency], nil
+}
@@ -0,0 +1,26 @@
+package testdata
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+type invoiceBuilder struct{ issued time.Time }
+
+func (b *invoiceBuilder) stampIssued() {
+	b.issued = time.Now() // should be flagged (reached through a local value)
+}
+
+type invoiceStore struct{}
+
+func (s invoiceStore) lastSaved() time.Time {
+	return time.Now() // should be flagged (reached through a struct-typed parameter)
+}
+
+func InvoiceWorkflow(ctx workflow.Context, store invoiceStore) error {
+	b := invoiceBuilder{}
+	b.stampIssued()
+	_ = store.lastSaved()
+	return nil
+}
@@ -0,0 +1,42 @@
+package testdata
+
+import (
+	"sort"
+
+	"go.uber.org/cadence/workflow"
+)
+
+type quotas map[string]int
+
+var defaultQuotas = quotas{"eu": 1, "us": 2}
+
+func FanOutByRegionWorkflow(ctx workflow.Context, weights map[string]int) error {
+	for region := range weights { // should be flagged (map parameter)
+		_ = workflow.ExecuteActivity(ctx, "Provision", region)
+	}
+	for region, q := range defaultQuotas { // should be flagged (package-level map of a named map type)
+		_, _ = region, q
+	}
+	seen := make(map[string]bool)
+	for k := range seen { // should be flagged (make(map...))
+		_ = k
+	}
+
+	regions := make([]string, 0, len(weights))
+	for region := range weights { // should be flagged
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	for _, region := range regions { // should NOT be flagged (slice)
+		_ = workflow.ExecuteActivity(ctx, "Provision", region)
+	}
+	return nil
+}
+
+func regionNames(weights map[string]int) []string {
+	var names []string
+	for region := range weights { // should NOT be flagged (not reachable from a workflow)
+		names = append(names, region)
+	}
+	return names
+}
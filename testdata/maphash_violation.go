@@ -0,0 +1,16 @@
+package testdata
+
+import (
+	"hash/maphash"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func MaphashWorkflow(ctx workflow.Context, key string) error {
+	seed := maphash.MakeSeed()    // should be flagged
+	_ = maphash.String(seed, key) // should be flagged
+	var h maphash.Hash            // should be flagged
+	_, _ = h.WriteString(key)
+	_ = h.Sum64()
+	return nil
+}
@@ -0,0 +1,32 @@
+package testdata
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+type auditClock struct{}
+
+func (c *auditClock) timestamp() time.Time {
+	return time.Now() // should be flagged (reachable from MethodHelperWorkflow)
+}
+
+type activityClock struct{}
+
+func (c activityClock) timestamp() time.Time {
+	return time.Now() // should NOT be flagged (only reachable from an activity)
+}
+
+func MethodHelperWorkflow(ctx workflow.Context) error {
+	c := &auditClock{}
+	_ = c.timestamp()
+	return nil
+}
+
+func MethodHelperActivity(ctx context.Context) error {
+	var c activityClock
+	_ = c.timestamp()
+	return nil
+}
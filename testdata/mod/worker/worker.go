@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"example.com/linttest/workflow"
+)
+
+// Worker holds workflows and activities registered as methods.
+type Worker struct {
+	started time.Time
+}
+
+// MyWorkflow is a method workflow; calling w.stamp makes the helper method reachable.
+func (w *Worker) MyWorkflow(ctx workflow.Context) error {
+	w.stamp()
+	return nil
+}
+
+func (w *Worker) stamp() {
+	w.started = time.Now() // should be flagged (reachable from (Worker).MyWorkflow)
+}
+
+// MyActivity is a method activity; its helper is not workflow code.
+func (w *Worker) MyActivity(ctx context.Context) error {
+	w.touch()
+	return nil
+}
+
+func (w *Worker) touch() {
+	w.started = time.Now() // should NOT be flagged (only reachable from an activity)
+}
@@ -0,0 +1,14 @@
+package testdata
+
+func mustValidate(qty int) error {
+	if qty > 1000 {
+		panic("quantity too large") // should be flagged (reachable from ValidatingWorkflow in another file)
+	}
+	return nil
+}
+
+func unusedValidator(qty int) {
+	if qty == 0 {
+		panic("zero") // should NOT be flagged (not reachable from a workflow)
+	}
+}
@@ -0,0 +1,21 @@
+package testdata
+
+import (
+	"context"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func ValidatingWorkflow(ctx workflow.Context, qty int) error {
+	if qty < 0 {
+		panic("negative quantity") // should be flagged
+	}
+	return mustValidate(qty)
+}
+
+func ValidatingActivity(ctx context.Context, qty int) error {
+	if qty < 0 {
+		panic("negative quantity") // should NOT be flagged (activity)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	"errors"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func RepanicWorkflow(ctx workflow.Context) error {
+	defer func() {
+		if r := recover(); r != nil { // should be flagged once (info)
+			workflow.GetLogger(ctx).Error("workflow panicked")
+			panic(r) // should NOT be flagged separately
+		}
+	}()
+	return nil
+}
+
+func PanickingWorkflow(ctx workflow.Context, ok bool) error {
+	if !ok {
+		panic("bad input") // should be flagged (warning)
+	}
+	return errors.New("done")
+}
@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	"context"
+	"math"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func QuotaWorkflow(ctx workflow.Context, quota int) error {
+	if quota == math.MaxInt { // should be flagged
+		return nil
+	}
+	if int64(quota) == math.MaxInt64 { // should NOT be flagged (sized constant)
+		return nil
+	}
+	limit := math.MaxInt // should NOT be flagged (not a branch)
+	_ = limit
+	return nil
+}
+
+func QuotaActivity(ctx context.Context, quota int) bool {
+	return quota == math.MaxInt || quota < math.MinInt+1 // should NOT be flagged (activity)
+}
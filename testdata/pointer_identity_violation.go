@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	"context"
+
+	"go.uber.org/cadence/workflow"
+)
+
+type shipment struct{ id string }
+
+func DedupeWorkflow(ctx workflow.Context, first *shipment) error {
+	second := &shipment{id: "s-1"}
+	if first == second { // should be flagged
+		return nil
+	}
+	if first != nil { // should NOT be flagged (nil check)
+		_ = first.id == second.id // should NOT be flagged (values, not pointers)
+	}
+	return nil
+}
+
+func DedupeActivity(ctx context.Context, a, b *shipment) bool {
+	return a == b // should NOT be flagged (activity)
+}
@@ -0,0 +1,26 @@
+package testdata
+
+import (
+	"go.uber.org/cadence/workflow"
+)
+
+func ShipItems(items []string) error { return nil }
+
+func BatchWorkflow(ctx workflow.Context, items []string) error {
+	f := workflow.ExecuteActivity(ctx, ShipItems, items)
+	items = append(items, "late") // should be flagged
+	items[0] = "changed"          // should be flagged
+	if err := f.Get(ctx, nil); err != nil {
+		return err
+	}
+	items[0] = "done" // should NOT be flagged (future already resolved)
+	return nil
+}
+
+func SyncBatchWorkflow(ctx workflow.Context, items []string) error {
+	if err := workflow.ExecuteActivity(ctx, ShipItems, items).Get(ctx, nil); err != nil {
+		return err
+	}
+	items = append(items, "next") // should NOT be flagged
+	return nil
+}
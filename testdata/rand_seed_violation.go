@@ -0,0 +1,17 @@
+package testdata
+
+import (
+	"math/rand"
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func SeedOutsideWorkflow() {
+	rand.Seed(time.Now().UnixNano()) // should NOT be flagged
+}
+
+func SeededRandWorkflow(ctx workflow.Context) error {
+	rand.Seed(time.Now().UnixNano()) // should be flagged once as Randomness
+	return nil
+}
@@ -0,0 +1,23 @@
+package testdata
+
+import (
+	"math/rand"
+	"os"
+	"time"
+)
+
+func reportTimestamp() time.Time {
+	return time.Now() // should be flagged (reachable from ReportingWorkflow in another file)
+}
+
+func reportSampleSize() int {
+	return rand.Intn(100) // should be flagged (reachable from ReportingWorkflow in another file)
+}
+
+func reportTemplate() ([]byte, error) {
+	return os.ReadFile("report.tmpl") // should be flagged (reachable from ReportingWorkflow in another file)
+}
+
+func unusedReportTimestamp() time.Time {
+	return time.Now() // should NOT be flagged (not reachable from a workflow)
+}
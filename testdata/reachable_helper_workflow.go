@@ -0,0 +1,12 @@
+package testdata
+
+import (
+	"go.uber.org/cadence/workflow"
+)
+
+func ReportingWorkflow(ctx workflow.Context) error {
+	_ = reportTimestamp()
+	_ = reportSampleSize()
+	_, _ = reportTemplate()
+	return nil
+}
@@ -0,0 +1,33 @@
+package testdata
+
+import (
+	"context"
+
+	"go.uber.org/cadence/workflow"
+)
+
+type orderWorkflows struct {
+	processed int
+	lastOrder string
+	byRegion  map[string]int
+	limit     int
+}
+
+func (w *orderWorkflows) ProcessOrder(ctx workflow.Context, id, region string) error {
+	w.processed++           // should be flagged
+	w.lastOrder = id        // should be flagged
+	w.byRegion[region] += 1 // should be flagged
+	limit := w.limit        // should NOT be flagged (read)
+	_ = limit
+	return nil
+}
+
+func (w orderWorkflows) Describe() string {
+	w.lastOrder = "copy" // should NOT be flagged (not reachable from a workflow)
+	return w.lastOrder
+}
+
+func (w *orderWorkflows) RecordActivity(ctx context.Context, id string) error {
+	w.lastOrder = id // should NOT be flagged (activity)
+	return nil
+}
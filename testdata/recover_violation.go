@@ -0,0 +1,26 @@
+package testdata
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func SafeguardedWorkflow(ctx workflow.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil { // should be flagged
+			err = fmt.Errorf("recovered: %v", r)
+		}
+	}()
+	return workflow.ExecuteActivity(ctx, "Step").Get(ctx, nil)
+}
+
+func SafeguardedActivity(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil { // should NOT be flagged (activity)
+			err = fmt.Errorf("recovered: %v", r)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,27 @@
+package testdata
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func RegexRuleWorkflow(ctx workflow.Context) error {
+	sess := session.Must(session.NewSession()) // should be flagged twice (aws package regex)
+	_ = s3.New(sess)                           // should be flagged (aws package regex)
+	var _ s3.Object                            // type reference, not a call
+	_ = regexp.MustCompile(`a+`)               // should be flagged (Must.* function regex)
+	_ = regexp.QuoteMeta("a")                  // not matched
+	_ = time.Now()                             // should be flagged (exact rule)
+	return nil
+}
+
+func RegexRuleActivity(ctx context.Context) error {
+	_ = regexp.MustCompile(`a+`) // should NOT be flagged (activity)
+	return nil
+}
@@ -0,0 +1,19 @@
+package testdata
+
+import (
+	rtdebug "runtime/debug"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func BatchImportWorkflow(ctx workflow.Context, batches int) error {
+	for i := 0; i < batches; i++ {
+		_ = workflow.ExecuteActivity(ctx, "ImportBatch", i).Get(ctx, nil)
+		rtdebug.FreeOSMemory() // should be flagged
+	}
+	return nil
+}
+
+func configureWorker() {
+	rtdebug.SetGCPercent(50) // should NOT be flagged (not reachable from a workflow)
+}
@@ -0,0 +1,20 @@
+package testdata
+
+import (
+	"runtime"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func NumCPUWorkflow(ctx workflow.Context) error {
+	workers := 1
+	if runtime.NumCPU() > 4 { // should be flagged
+		workers = 4
+	}
+	_ = workers
+	return nil
+}
+
+func NumCPUActivity() int {
+	return runtime.NumCPU() // should NOT be flagged
+}
@@ -0,0 +1,33 @@
+package testdata
+
+import (
+	"context"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func PollingWorkflow(ctx workflow.Context, done chan struct{}) error {
+	for {
+		select { // should be flagged (error: busy poll)
+		case <-done:
+			return nil
+		default:
+		}
+	}
+}
+
+func WaitingWorkflow(ctx workflow.Context, done chan struct{}) error {
+	select { // should be flagged (warning: native select)
+	case <-done:
+	}
+	return nil
+}
+
+func PollingActivity(ctx context.Context, done chan struct{}) bool {
+	select { // should NOT be flagged
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
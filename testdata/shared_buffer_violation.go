@@ -0,0 +1,27 @@
+package testdata
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func ReportWorkflow(ctx workflow.Context, lines []string) (string, error) {
+	var sb strings.Builder
+	out := &bytes.Buffer{}
+	go func() { // should be flagged (goroutine)
+		for _, l := range lines {
+			sb.WriteString(l) // should be flagged (shared builder)
+			sb.WriteByte('\n')
+		}
+		fmt.Fprintf(out, "%d lines", len(lines)) // should be flagged (shared buffer)
+	}()
+	go func() { // should be flagged (goroutine only)
+		var own bytes.Buffer
+		own.WriteString("private")
+		_ = sb.Len()
+	}()
+	return sb.String(), nil
+}
@@ -0,0 +1,23 @@
+package testdata
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func BackoffWorkflow(ctx workflow.Context) error {
+	time.Sleep(5 * time.Second) // should be flagged
+	return pollDelay()
+}
+
+func pollDelay() error {
+	time.Sleep(time.Second) // should be flagged (reachable from BackoffWorkflow)
+	return nil
+}
+
+func BackoffActivity(ctx context.Context) error {
+	time.Sleep(5 * time.Second) // should NOT be flagged
+	return nil
+}
@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/cadence/workflow"
+)
+
+type byPriority []string
+
+func (p byPriority) Len() int           { return len(p) }
+func (p byPriority) Less(i, j int) bool { return len(p[i]) < len(p[j]) }
+func (p byPriority) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+func PrioritizeWorkflow(ctx workflow.Context, tasks []string) error {
+	sort.Sort(byPriority(tasks)) // should be flagged
+	return nil
+}
+
+func PrioritizeActivity(ctx context.Context, tasks []string) error {
+	sort.Stable(byPriority(tasks)) // should NOT be flagged (activity)
+	return nil
+}
@@ -0,0 +1,21 @@
+package testdata
+
+import (
+	"math/rand"
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func SuppressedWorkflow(ctx workflow.Context) error {
+	_ = time.Now() //workflow-lint:ignore TimeUsage
+	//workflow-lint:ignore TimeUsage reviewed: only logged, never branched on
+	_ = time.Now()
+	_ = time.Now() // should be flagged (not suppressed)
+	//workflow-lint:ignore TimeUsage
+	_ = rand.Intn(10) // should be flagged (a different rule)
+	//workflow-lint:ignore
+	_, _ = time.Now(), rand.Intn(10)
+	_ = rand.Intn(10) //workflow-lint:ignore Randomness,TimeUsage
+	return nil
+}
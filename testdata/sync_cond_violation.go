@@ -0,0 +1,37 @@
+package testdata
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func ReadyGateWorkflow(ctx workflow.Context) error {
+	var mu sync.Mutex          // flagged as a sync.Mutex variable
+	ready := sync.NewCond(&mu) // should be flagged (sync.NewCond)
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		ready.Broadcast() // should be flagged
+	})
+	ready.L.Lock()
+	ready.Wait() // should be flagged
+	return nil
+}
+
+func waitReady(c *sync.Cond) {
+	c.Signal() // should be flagged (reached from WaitReadyWorkflow)
+}
+
+func WaitReadyWorkflow(ctx workflow.Context, c *sync.Cond) error {
+	waitReady(c)
+	return nil
+}
+
+func ReadyGateActivity(ctx context.Context) error {
+	var mu sync.Mutex
+	ready := sync.NewCond(&mu) // should NOT be flagged (activity)
+	go ready.Broadcast()
+	ready.L.Lock()
+	ready.Wait()
+	return nil
+}
@@ -0,0 +1,20 @@
+package testdata
+
+import (
+	"sync"
+
+	"go.uber.org/cadence/workflow"
+)
+
+var sharedCache sync.Map
+
+func SyncMapWorkflow(ctx workflow.Context) error {
+	local := &sync.Map{}
+	local.Range(func(k, v interface{}) bool { return true })       // should be flagged
+	sharedCache.Range(func(k, v interface{}) bool { return true }) // should be flagged
+	return nil
+}
+
+func SyncMapHelperOutsideWorkflow() {
+	sharedCache.Range(func(k, v interface{}) bool { return true }) // should NOT be flagged
+}
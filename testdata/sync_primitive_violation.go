@@ -0,0 +1,40 @@
+package testdata
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func ParallelStepsWorkflow(ctx workflow.Context, steps []string) error {
+	var wg sync.WaitGroup // should be flagged (declaration)
+	for _, s := range steps {
+		wg.Add(1) // should be flagged
+		step := s
+		workflow.Go(ctx, func(ctx workflow.Context) {
+			defer wg.Done() // should be flagged
+			_ = workflow.ExecuteActivity(ctx, "Step", step).Get(ctx, nil)
+		})
+	}
+	wg.Wait() // should be flagged
+	return nil
+}
+
+func ParallelStepsActivity(ctx context.Context, steps []string) error {
+	var wg sync.WaitGroup // should NOT be flagged (activity)
+	for range steps {
+		wg.Add(1)
+		go wg.Done()
+	}
+	wg.Wait()
+	return nil
+}
+
+var stepsMu sync.Mutex // should NOT be flagged (package-level declaration)
+
+func LockedStepWorkflow(ctx workflow.Context) error {
+	stepsMu.Lock()         // should be flagged
+	defer stepsMu.Unlock() // should be flagged
+	return nil
+}
@@ -0,0 +1,27 @@
+package testdata
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func ScratchWorkflow(ctx workflow.Context) error {
+	f, err := os.CreateTemp("", "report-*.csv") // should be flagged
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	dir, err := os.MkdirTemp(os.TempDir(), "scratch") // should be flagged (twice)
+	if err != nil {
+		return err
+	}
+	_, err = ioutil.TempFile(dir, "legacy") // should be flagged
+	return err
+}
+
+func ScratchActivity(ctx context.Context) (string, error) {
+	return ioutil.TempDir("", "scratch") // should NOT be flagged
+}
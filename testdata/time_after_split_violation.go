@@ -0,0 +1,26 @@
+package testdata
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func SplitTimeoutWorkflow(ctx workflow.Context) error {
+	timeout := time.After(30 * time.Second)
+	<-timeout // should be flagged
+
+	var ticks = time.Tick(time.Minute)
+	for range ticks { // should be flagged
+		break
+	}
+
+	done := make(chan struct{}) // flagged as channel creation only
+	<-done                      // should NOT be flagged as a timer
+	return nil
+}
+
+func splitTimeoutHelper() {
+	c := time.After(time.Second)
+	<-c // should NOT be flagged (not reachable from a workflow)
+}
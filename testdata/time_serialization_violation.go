@@ -0,0 +1,23 @@
+package testdata
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+type ShipmentRequest struct {
+	OrderID  string
+	Deadline time.Time
+}
+
+func TimeArgWorkflow(ctx workflow.Context, deadline time.Time) error {
+	now := workflow.Now(ctx)
+	_ = workflow.ExecuteActivity(ctx, ScheduleShipment, now)      // should be flagged
+	_ = workflow.ExecuteActivity(ctx, ScheduleShipment, deadline) // should be flagged
+	req := ShipmentRequest{OrderID: "42", Deadline: now}
+	_ = workflow.ExecuteActivity(ctx, ShipOrder, req)                     // should be flagged
+	_ = workflow.ExecuteActivity(ctx, ScheduleShipment, now.UTC().Unix()) // should NOT be flagged
+	_ = workflow.ExecuteActivity(ctx, ShipOrder, "order-42")              // should NOT be flagged
+	return nil
+}
@@ -0,0 +1,22 @@
+package testdata
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func ReminderWorkflow(ctx workflow.Context) error {
+	select {
+	case <-time.After(time.Hour): // should be flagged
+	}
+	return nil
+}
+
+func HeartbeatActivity(ctx context.Context) error {
+	ticker := time.NewTicker(10 * time.Second) // should NOT be flagged (activity)
+	defer ticker.Stop()
+	<-ticker.C
+	return nil
+}
@@ -0,0 +1,15 @@
+package testdata
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func OrderIDWorkflow(ctx workflow.Context) error {
+	orderID := fmt.Sprintf("order-%d", time.Now().UnixNano()) // should be flagged once
+	_ = orderID
+	_ = time.Now() // should be flagged (generic time.Now)
+	return nil
+}
@@ -0,0 +1,19 @@
+package testdata
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func DeadlineWorkflow(ctx workflow.Context, start, deadline time.Time) error {
+	_ = time.Until(deadline) // should be flagged
+	_ = time.Since(start)    // should be flagged
+	_ = deadline.Sub(start)  // should NOT be flagged
+	_ = workflow.Now(ctx).Sub(start)
+	return nil
+}
+
+func DeadlineActivity(deadline time.Time) time.Duration {
+	return time.Until(deadline) // should NOT be flagged
+}
@@ -0,0 +1,21 @@
+package testdata
+
+import (
+	"time"
+
+	"go.uber.org/cadence/workflow"
+)
+
+func TickerWorkflow(ctx workflow.Context) error {
+	ticker := time.NewTicker(time.Second) // should be flagged (never stopped)
+	<-ticker.C
+
+	timer := time.NewTimer(time.Minute) // should NOT be flagged (stopped)
+	defer timer.Stop()
+	return nil
+}
+
+func TickerActivity() {
+	ticker := time.NewTicker(time.Second) // should NOT be flagged (not a workflow)
+	<-ticker.C
+}
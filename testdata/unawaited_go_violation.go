@@ -0,0 +1,29 @@
+package testdata
+
+import (
+	"go.uber.org/cadence/workflow"
+)
+
+func FireAndForgetWorkflow(ctx workflow.Context) error {
+	workflow.Go(ctx, func(ctx workflow.Context) { // should be flagged
+		_ = workflow.Sleep(ctx, 0)
+	})
+	return nil
+}
+
+func FanOutWorkflow(ctx workflow.Context) error {
+	done := workflow.NewChannel(ctx)
+	workflow.Go(ctx, func(ctx workflow.Context) { // should NOT be flagged (awaited below)
+		done.Send(ctx, true)
+	})
+	done.Receive(ctx, nil)
+	return nil
+}
+
+func SelfWaitingWorkflow(ctx workflow.Context) error {
+	ch := workflow.NewChannel(ctx)
+	workflow.Go(ctx, func(ctx workflow.Context) { // should be flagged (the receive is inside the coroutine)
+		ch.Receive(ctx, nil)
+	})
+	return nil
+}
@@ -0,0 +1,29 @@
+package testdata
+
+import (
+	"context"
+
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/workflow"
+)
+
+func shipProduct(ctx context.Context, orderID string) error { return nil }
+
+func chargeCard(ctx context.Context, orderID string) error { return nil }
+
+func init() {
+	activity.RegisterWithOptions(shipProduct, activity.RegisterOptions{Name: "shipProduct"})
+	activity.Register(chargeCard)
+}
+
+func FulfillOrderWorkflow(ctx workflow.Context, orderID string) error {
+	if err := workflow.ExecuteActivity(ctx, "chargeCard", orderID).Get(ctx, nil); err != nil { // registered by function name
+		return err
+	}
+	if err := workflow.ExecuteActivity(ctx, "shipProdcut", orderID).Get(ctx, nil); err != nil { // should be flagged (typo)
+		return err
+	}
+	name := "notify"
+	_ = workflow.ExecuteActivity(ctx, name, orderID) // non-literal name, skipped
+	return workflow.ExecuteActivity(ctx, "shipProduct", orderID).Get(ctx, nil)
+}
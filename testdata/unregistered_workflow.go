@@ -0,0 +1,22 @@
+package testdata
+
+import (
+	"go.uber.org/cadence/workflow"
+)
+
+func init() {
+	workflow.Register(RegisteredReportWorkflow)
+}
+
+func RegisteredReportWorkflow(ctx workflow.Context) error {
+	return buildReport(ctx)
+}
+
+// buildReport is a helper called by a workflow, not a workflow itself.
+func buildReport(ctx workflow.Context) error {
+	return nil
+}
+
+func ForgottenReportWorkflow(ctx workflow.Context) error { // should be flagged
+	return nil
+}
@@ -0,0 +1,21 @@
+package testdata
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/cadence/workflow"
+)
+
+func TimeOrderedIDWorkflow(ctx workflow.Context) (string, error) {
+	id, err := uuid.NewUUID() // should be flagged
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+func TimeOrderedIDActivity(ctx context.Context) (string, error) {
+	id, err := uuid.NewUUID() // should NOT be flagged
+	return id.String(), err
+}
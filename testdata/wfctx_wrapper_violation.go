@@ -0,0 +1,21 @@
+package testdata
+
+import (
+	"time"
+
+	"example.com/linttest/wfctx"
+)
+
+// In-house wrapper package: wfctx.Context embeds workflow.Context.
+func stampOrder(ctx wfctx.Context) time.Time {
+	return time.Now() // should be flagged when wfctx is a workflow context package
+}
+
+func stampOrderHelper() time.Time {
+	return time.Now() // should be flagged (reachable from stampOrder)
+}
+
+func StampBoth(ctx wfctx.Context) {
+	_ = stampOrder(ctx)
+	_ = stampOrderHelper()
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
@@ -53,8 +54,11 @@ func importMapFromFile(node *ast.File) map[string]string {
 
 func walkOnce(t *testing.T, v ast.Visitor, fset *token.FileSet, node *ast.File, filename string) []detectors.Issue {
 	t.Helper()
+	return walkWithRegistry(t, v, registry.NewWorkflowRegistry(), fset, node, filename)
+}
 
-	reg := registry.NewWorkflowRegistry() // keep current (non-config) registry
+func walkWithRegistry(t *testing.T, v ast.Visitor, reg *registry.WorkflowRegistry, fset *token.FileSet, node *ast.File, filename string) []detectors.Issue {
+	t.Helper()
 
 	// Set package path for proper workflow detection
 	pkgPath := "testdata/testdata"
@@ -137,6 +141,61 @@ func TestGoroutineDetector(t *testing.T) {
 	if len(issues) == 0 {
 		t.Fatalf("expected at least one goroutine issue in %s", file)
 	}
+	if issues[0].Func != "GoroutineWorkflow" {
+		t.Errorf("expected the issue in GoroutineWorkflow, got %+v", issues[0])
+	}
+}
+
+func TestGoroutineDetector_ActivityNotFlagged(t *testing.T) {
+	fset, node, file := parse(t, "activity_ok.go")
+	d := detectors.NewGoroutineDetector()
+	if issues := walkOnce(t, d, fset, node, file); len(issues) != 0 {
+		t.Fatalf("expected no goroutine issues in activities, got %+v", issues)
+	}
+}
+
+func TestGoroutineDetector_CapturedWorkflowContext(t *testing.T) {
+	fset, node, file := parse(t, "goroutine_ctx_violation.go")
+	d := detectors.NewGoroutineDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 goroutine issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for i, is := range issues {
+		captured := strings.Contains(is.Message, "handed the workflow.Context")
+		if captured != (i < 2) {
+			t.Errorf("issue %d: unexpected message %q", i, is.Message)
+		}
+	}
+}
+
+func TestGoroutineDetector_SharedBuffer(t *testing.T) {
+	fset, node, file := parse(t, "shared_buffer_violation.go")
+	d := detectors.NewGoroutineDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	var goroutines, shared []detectors.Issue
+	for _, is := range issues {
+		switch is.Rule {
+		case "Concurrency":
+			goroutines = append(goroutines, is)
+		case "SharedBuffer":
+			shared = append(shared, is)
+		}
+	}
+	if len(goroutines) != 2 {
+		t.Errorf("expected 2 goroutine issues, got %+v", goroutines)
+	}
+	if len(shared) != 2 {
+		t.Fatalf("expected 2 SharedBuffer issues in %s, got %+v", file, shared)
+	}
+	for _, is := range shared {
+		if is.Severity != "info" {
+			t.Errorf("unexpected severity: %+v", is)
+		}
+	}
+	if !strings.Contains(shared[0].Message, "sb (strings.Builder)") || !strings.Contains(shared[1].Message, "out (bytes.Buffer)") {
+		t.Errorf("unexpected messages: %q, %q", shared[0].Message, shared[1].Message)
+	}
 }
 
 func TestChannelDetector(t *testing.T) {
@@ -148,6 +207,23 @@ func TestChannelDetector(t *testing.T) {
 	}
 }
 
+func TestChannelDetector_SplitTimeAfter(t *testing.T) {
+	fset, node, file := parse(t, "time_after_split_violation.go")
+	d := detectors.NewChannelDetector()
+	var timers []detectors.Issue
+	for _, is := range walkOnce(t, d, fset, node, file) {
+		if is.Rule == "TimeUsage" {
+			timers = append(timers, is)
+		}
+	}
+	if len(timers) != 2 {
+		t.Fatalf("expected 2 TimeUsage issues in %s, got %+v", file, timers)
+	}
+	if !strings.Contains(timers[0].Message, "time.After()") || !strings.Contains(timers[1].Message, "time.Tick()") {
+		t.Errorf("unexpected messages: %q, %q", timers[0].Message, timers[1].Message)
+	}
+}
+
 func TestActivityNotFlagged(t *testing.T) {
 	rules, err := config.LoadRules("../config/rules.yaml")
 	if err != nil {
@@ -162,3 +238,1200 @@ func TestActivityNotFlagged(t *testing.T) {
 		t.Fatalf("expected 0 issues in activities, got %d", len(issues))
 	}
 }
+
+func TestFuncCallDetector_TimeSeededRand(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "rand_seed_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected exactly 1 issue for seeded rand in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if issues[0].Rule != "Randomness" || issues[0].Func != "SeededRandWorkflow" {
+		t.Fatalf("expected Randomness issue in SeededRandWorkflow, got %+v", issues[0])
+	}
+}
+
+func TestFuncCallDetector_TimeSeededRandFollowsRules(t *testing.T) {
+	fset, node, file := parse(t, "rand_seed_violation.go")
+
+	// Without a math/rand rule the seed isn't reported as Randomness; the
+	// time.Now() inside it falls to whatever time rule is configured (none).
+	ioOnly := []config.FunctionRule{{Rule: "IOCalls", Package: "fmt", Functions: []string{"Println"}, Severity: "warning"}}
+	if issues := walkOnce(t, detectors.NewFuncCallDetector(ioOnly, nil, nil, nil), fset, node, file); len(issues) != 0 {
+		t.Fatalf("expected no issues without a math/rand rule, got %+v", issues)
+	}
+
+	randRule := []config.FunctionRule{{Rule: "SeededRandom", Package: "math/rand", Functions: []string{"Intn"}, Severity: "warning", MessageTemplate: "custom: %MESSAGE%"}}
+	issues := walkOnce(t, detectors.NewFuncCallDetector(randRule, nil, nil, nil), fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if is := issues[0]; is.Rule != "SeededRandom" || is.Severity != "warning" || !strings.HasPrefix(is.Message, "custom: Detected rand.Seed() seeded from time.Now()") {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestActivityArgDetector_TimeSerialization(t *testing.T) {
+	fset, node, file := parse(t, "time_serialization_violation.go")
+	d := detectors.NewActivityArgDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 TimeSerialization issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Rule != "TimeSerialization" || is.Severity != "info" {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestSyncMapDetector(t *testing.T) {
+	fset, node, file := parse(t, "sync_map_violation.go")
+	d := detectors.NewSyncMapDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 sync.Map.Range issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Func != "SyncMapWorkflow" {
+			t.Errorf("expected issue in SyncMapWorkflow, got %+v", is)
+		}
+	}
+}
+
+func TestActivityArgDetector_Closures(t *testing.T) {
+	fset, node, file := parse(t, "activity_closure_violation.go")
+	d := detectors.NewActivityArgDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 4 {
+		t.Fatalf("expected 4 Serialization issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Rule != "Serialization" || is.Severity != "error" {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestFuncCallDetector_RuntimeNumCPU(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "runtime_numcpu_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 || issues[0].Rule != "RuntimeUsage" || issues[0].Severity != "warning" {
+		t.Fatalf("expected one RuntimeUsage warning in %s, got %+v", file, issues)
+	}
+}
+
+func TestFuncCallDetector_StructMethodHelper(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "method_time_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 TimeUsage issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if issues[0].Rule != "TimeUsage" || issues[0].Func != "(auditClock).timestamp" {
+		t.Fatalf("expected TimeUsage in (auditClock).timestamp, got %+v", issues[0])
+	}
+	want := []string{"testdata/testdata.MethodHelperWorkflow", "testdata/testdata.(auditClock).timestamp"}
+	if strings.Join(issues[0].CallStack, " -> ") != strings.Join(want, " -> ") {
+		t.Fatalf("unexpected call stack %v", issues[0].CallStack)
+	}
+}
+
+func TestRegistry_MethodWorkflows(t *testing.T) {
+	_, node, _ := parse(t, "mod/worker/worker.go")
+	reg := registry.NewWorkflowRegistry()
+	reg.ProcessFile(node, "example.com/linttest/worker", importMapFromFile(node))
+
+	wf := registry.ParseFuncID("example.com/linttest/worker.(Worker).MyWorkflow")
+	if !reg.WorkflowFuncs[wf] {
+		t.Fatalf("expected %s to be classified as a workflow", wf)
+	}
+	if act := registry.ParseFuncID("example.com/linttest/worker.(Worker).MyActivity"); !reg.ActivityFuncs[act] {
+		t.Fatalf("expected %s to be classified as an activity", act)
+	}
+	if helper := registry.ParseFuncID("example.com/linttest/worker.(Worker).stamp"); !reg.IsWorkflowReachable(helper) {
+		t.Fatalf("expected %s to be reachable from the method workflow", helper)
+	}
+	if helper := registry.ParseFuncID("example.com/linttest/worker.(Worker).touch"); reg.IsWorkflowReachable(helper) {
+		t.Fatalf("expected %s, only called from an activity, not to be reachable", helper)
+	}
+}
+
+func TestFuncCallDetector_LocalStructMethods(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "local_struct_method_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 TimeUsage issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for i, fn := range []string{"(invoiceBuilder).stampIssued", "(invoiceStore).lastSaved"} {
+		if issues[i].Rule != "TimeUsage" || issues[i].Func != fn {
+			t.Errorf("expected TimeUsage in %s, got %+v", fn, issues[i])
+		}
+	}
+}
+
+func TestRegistry_ActivityLiteralEdges(t *testing.T) {
+	_, node, _ := parse(t, "closure_violation.go")
+	reg := registry.NewWorkflowRegistry()
+	reg.ProcessFile(node, "testdata/testdata", importMapFromFile(node))
+
+	if helper := registry.ParseFuncID("testdata/testdata.closureAuditStamp"); reg.IsWorkflowReachable(helper) {
+		t.Fatalf("expected %s, only called from a local activity literal, not to be reachable", helper)
+	}
+}
+
+func TestFuncCallDetector_Maphash(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "maphash_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 maphash issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Rule != "Randomness" || is.Severity != "warning" {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestDeferLoopDetector(t *testing.T) {
+	fset, node, file := parse(t, "defer_loop_violation.go")
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+	d := detectors.NewDeferLoopDetector(rules.FunctionCalls)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 DeferInLoop issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Severity != "info" {
+			t.Errorf("expected info severity, got %+v", is)
+		}
+	}
+	if !strings.Contains(issues[0].Message, "os.Remove() is itself reported as IOCalls") {
+		t.Errorf("expected deferred os.Remove to name its rule, got %q", issues[0].Message)
+	}
+	if strings.Contains(issues[1].Message, "itself reported") {
+		t.Errorf("expected plain defer message for release, got %q", issues[1].Message)
+	}
+}
+
+func TestExcludeFunctions(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "method_time_violation.go")
+	reg := registry.NewWorkflowRegistry()
+	if err := reg.ExcludeFunctions([]string{"testdata/*.(auditClock).*"}, false); err != nil {
+		t.Fatalf("exclude: %v", err)
+	}
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkWithRegistry(t, d, reg, fset, node, file)
+	if len(issues) != 0 {
+		t.Fatalf("expected excluded helper not to be flagged, got %+v", issues)
+	}
+}
+
+func TestExcludeFunctions_Opaque(t *testing.T) {
+	_, node, _ := parse(t, "method_time_violation.go")
+	reg := registry.NewWorkflowRegistry()
+	reg.ProcessFile(node, "testdata/testdata", importMapFromFile(node))
+
+	helper := registry.ParseFuncID("testdata/testdata.(auditClock).timestamp")
+	if err := reg.ExcludeFunctions([]string{"testdata/testdata.MethodHelperWorkflow"}, false); err != nil {
+		t.Fatalf("exclude: %v", err)
+	}
+	if !reg.IsWorkflowReachable(helper) {
+		t.Fatalf("expected %s to stay reachable through a non-opaque exclusion", helper)
+	}
+
+	opaque := registry.NewWorkflowRegistry()
+	opaque.ProcessFile(node, "testdata/testdata", importMapFromFile(node))
+	if err := opaque.ExcludeFunctions([]string{"testdata/testdata.MethodHelperWorkflow"}, true); err != nil {
+		t.Fatalf("exclude: %v", err)
+	}
+	if opaque.IsWorkflowReachable(helper) {
+		t.Fatalf("expected opaque exclusion to hide %s", helper)
+	}
+}
+
+func TestFuncCallDetector_TimeSinceUntil(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "time_until_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 TimeUsage issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Rule != "TimeUsage" || !strings.Contains(is.Message, "workflow.Now(ctx)") {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+	if !strings.Contains(issues[0].Message, "time.Until()") {
+		t.Errorf("expected time.Until in message, got %q", issues[0].Message)
+	}
+}
+
+func TestFuncCallDetector_NetworkIO(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "grpc_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	var flagged []detectors.Issue
+	for _, is := range walkOnce(t, d, fset, node, file) {
+		if is.Rule == "NetworkIO" {
+			flagged = append(flagged, is)
+		}
+	}
+	if len(flagged) != 2 {
+		t.Fatalf("expected 2 NetworkIO issues in %s, got %d: %+v", file, len(flagged), flagged)
+	}
+	if flagged[0].Line != 12 || flagged[1].Line != 18 || flagged[0].Severity != "error" {
+		t.Fatalf("unexpected NetworkIO issues: %+v", flagged)
+	}
+}
+
+func TestChannelDetector_SelectDefault(t *testing.T) {
+	fset, node, file := parse(t, "select_default_violation.go")
+	d := detectors.NewChannelDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 select issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if issues[0].Severity != "error" || !strings.Contains(issues[0].Message, "busy-spins") {
+		t.Errorf("expected defaulted select to be an error about busy polling, got %+v", issues[0])
+	}
+	if issues[1].Severity != "warning" {
+		t.Errorf("expected plain select to be a warning, got %+v", issues[1])
+	}
+}
+
+func TestFuncCallDetector_FilesystemReads(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "fs_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 IOCalls issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Rule != "IOCalls" || is.Severity != "warning" {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestTimeSleepDetection(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "sleep_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 TimeUsage issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Rule != "TimeUsage" || is.Func == "BackoffActivity" || !strings.Contains(is.Message, "workflow.Sleep(ctx, d)") {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestEnvironmentAccessDetection(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "env_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 EnvironmentAccess issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for i, want := range []string{"RegionalWorkflow", "defaultRegion"} {
+		if is := issues[i]; is.Rule != "EnvironmentAccess" || is.Func != want {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestContextBackgroundDetection(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "context_background_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 ContextMisuse issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for i, want := range []string{"MovedCodeWorkflow", "lookupCustomer"} {
+		if is := issues[i]; is.Rule != "ContextMisuse" || is.Severity != "error" || is.Func != want {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestRuntimeDebugDetection(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "runtime_debug_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 RuntimeUsage issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if is := issues[0]; is.Rule != "RuntimeUsage" || is.Severity != "warning" || !strings.Contains(is.Message, "debug.FreeOSMemory()") {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestTimeBasedUUIDDetection(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "uuid_v1_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	var uuids []detectors.Issue
+	for _, is := range walkOnce(t, d, fset, node, file) {
+		if is.Rule == "UUIDGeneration" {
+			uuids = append(uuids, is)
+		}
+	}
+	if len(uuids) != 1 {
+		t.Fatalf("expected 1 UUIDGeneration issue in %s, got %+v", file, uuids)
+	}
+	if is := uuids[0]; is.Func != "TimeOrderedIDWorkflow" || !strings.Contains(is.Message, "Time-based UUIDs") {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestTimeNowUnixDetection(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "time_unix_id_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	var timeIssues []detectors.Issue
+	for _, is := range issues {
+		if is.Rule == "TimeUsage" {
+			timeIssues = append(timeIssues, is)
+		}
+	}
+	if len(timeIssues) != 2 {
+		t.Fatalf("expected 2 TimeUsage issues in %s, got %d: %+v", file, len(timeIssues), timeIssues)
+	}
+	if is := timeIssues[0]; is.Line != 11 || !strings.Contains(is.Message, "time.Now().UnixNano()") || is.SuggestedFix == nil {
+		t.Errorf("expected the chained call reported once with a fix, got %+v", is)
+	}
+	if is := timeIssues[1]; is.Line != 13 || strings.Contains(is.Message, "UnixNano") {
+		t.Errorf("expected the plain time.Now() to keep the generic message, got %+v", is)
+	}
+}
+
+func TestFuncCallDetector_TimeNowUnixTemplate(t *testing.T) {
+	fset, node, file := parse(t, "time_unix_id_violation.go")
+	rules := []config.FunctionRule{{Rule: "TimeUsage", Package: "time", Functions: []string{"Now"}, Severity: "error", Message: "clock", MessageTemplate: "[%FILE%:%LINE%] %MESSAGE%"}}
+	issues := walkOnce(t, detectors.NewFuncCallDetector(rules, nil, nil, nil), fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 TimeUsage issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for i, want := range []string{
+		"[" + file + ":11] Detected time.Now().UnixNano() in workflow.",
+		"[" + file + ":13] clock",
+	} {
+		if !strings.HasPrefix(issues[i].Message, want) {
+			t.Errorf("issue %d: got message %q, want prefix %q", i, issues[i].Message, want)
+		}
+	}
+}
+
+func TestTimerConstructorDetection(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "time_timer_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 TimeUsage issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if is := issues[0]; is.Rule != "TimeUsage" || is.Func != "ReminderWorkflow" || !strings.Contains(is.Message, "workflow.NewTimer(ctx, d)") {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestSortInterfaceDetection(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "sort_interface_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 sort issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if is := issues[0]; is.Rule != "NonDeterminism" || is.Severity != "info" || is.Func != "PrioritizeWorkflow" {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestTempFileDetection(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "tempfile_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 4 {
+		t.Fatalf("expected 4 IOCalls issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Rule != "IOCalls" || is.Severity != "error" {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestWorkflowContextPackages(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "wfctx_wrapper_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	if issues := walkOnce(t, d, fset, node, file); len(issues) != 0 {
+		t.Fatalf("expected no issues without workflow_context_packages, got %+v", issues)
+	}
+
+	reg := registry.NewWorkflowRegistry()
+	reg.SetWorkflowContextPackages([]string{"example.com/linttest/wfctx"})
+	d = detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkWithRegistry(t, d, reg, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 TimeUsage issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+}
+
+func TestPostCallMutationDetector(t *testing.T) {
+	fset, node, file := parse(t, "post_call_mutation_violation.go")
+	d := detectors.NewPostCallMutationDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 PostCallMutation issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if issues[0].Line != 11 || issues[1].Line != 12 || issues[0].Severity != "info" {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+}
+
+func TestBusyWaitDetector(t *testing.T) {
+	fset, node, file := parse(t, "busy_wait_violation.go")
+	d := detectors.NewBusyWaitDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 BusyWait issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if issues[0].Rule != "BusyWait" || issues[0].Severity != "warning" || issues[0].Line != 10 {
+		t.Fatalf("unexpected issue: %+v", issues[0])
+	}
+}
+
+func TestFuncCallDetector_Gob(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "gob_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 GobEncoding issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Rule != "GobEncoding" || is.Severity != "info" {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestPanicDetector_RecoverRepanic(t *testing.T) {
+	fset, node, file := parse(t, "panic_violation.go")
+	d := detectors.NewPanicDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	var repanic []detectors.Issue
+	for _, is := range issues {
+		if is.Func == "RepanicWorkflow" {
+			repanic = append(repanic, is)
+		}
+	}
+	if len(repanic) != 1 || repanic[0].Rule != "Recover" || repanic[0].Severity != "info" {
+		t.Fatalf("expected exactly one Recover info for recover-then-repanic, got %+v", repanic)
+	}
+}
+
+func TestFuncCallDetector_CrossFileHelpers(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	_, wfNode, _ := parse(t, "reachable_helper_workflow.go")
+	helperFset, helperNode, helperFile := parse(t, "reachable_helper_util.go")
+
+	// Register the workflow file first so the helpers are reachable through it.
+	reg := registry.NewWorkflowRegistry()
+	reg.ProcessFile(wfNode, "testdata/testdata", importMapFromFile(wfNode))
+
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkWithRegistry(t, d, reg, helperFset, helperNode, helperFile)
+	want := []struct{ rule, fn string }{
+		{"TimeUsage", "reportTimestamp"},
+		{"Randomness", "reportSampleSize"},
+		{"IOCalls", "reportTemplate"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d issues in helpers, got %d: %+v", len(want), len(issues), issues)
+	}
+	for i, w := range want {
+		if is := issues[i]; is.Rule != w.rule || is.Func != w.fn {
+			t.Errorf("expected %s in %s, got %+v", w.rule, w.fn, is)
+		}
+	}
+}
+
+func TestPanicDetector_CrossFileReachability(t *testing.T) {
+	wfFset, wfNode, _ := parse(t, "panic_cross_file_workflow.go")
+	helperFset, helperNode, helperFile := parse(t, "panic_cross_file_helper.go")
+
+	// Register the workflow file first so the helper is reachable through it.
+	reg := registry.NewWorkflowRegistry()
+	reg.ProcessFile(wfNode, "testdata/testdata", importMapFromFile(wfNode))
+
+	var issues []detectors.Issue
+	issues = append(issues, walkWithRegistry(t, detectors.NewPanicDetector(), reg, wfFset, wfNode, "panic_cross_file_workflow.go")...)
+	issues = append(issues, walkWithRegistry(t, detectors.NewPanicDetector(), reg, helperFset, helperNode, helperFile)...)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 Panic issues, got %d: %+v", len(issues), issues)
+	}
+	for i, want := range []string{"ValidatingWorkflow", "mustValidate"} {
+		if is := issues[i]; is.Rule != "Panic" || is.Severity != "warning" || is.Func != want {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestPanicDetector_DeferredRecover(t *testing.T) {
+	fset, node, file := parse(t, "recover_violation.go")
+	d := detectors.NewPanicDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if is := issues[0]; is.Rule != "Panic" || is.Severity != "warning" || is.Func != "SafeguardedWorkflow" || !strings.Contains(is.Message, "recover") {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestClockDetector(t *testing.T) {
+	fset, node, file := parse(t, "clock_violation.go")
+	d := detectors.NewClockDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 ClockAbstraction issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Rule != "ClockAbstraction" || is.Severity != "info" || is.Func != "ClockWorkflow" {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestFuncCallDetector_UnknownExternalCallSetting(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+	fset, node, file := parse(t, "unknown_external_test.go")
+
+	count := func(setting string) (n int, severity string) {
+		d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+		d.SetUnknownExternalCall(setting)
+		for _, is := range walkOnce(t, d, fset, node, file) {
+			if is.Rule == "UnknownExternalCall" {
+				n++
+				severity = is.Severity
+			}
+		}
+		return n, severity
+	}
+
+	if n, sev := count(""); n != 2 || sev != "info" {
+		t.Fatalf("expected 2 info UnknownExternalCall issues by default, got %d (%s)", n, sev)
+	}
+	if n, sev := count("warning"); n != 2 || sev != "warning" {
+		t.Fatalf("expected 2 warning UnknownExternalCall issues, got %d (%s)", n, sev)
+	}
+	if n, _ := count("off"); n != 0 {
+		t.Fatalf("expected no UnknownExternalCall issues when off, got %d", n)
+	}
+}
+
+func TestTimerStopDetector(t *testing.T) {
+	fset, node, file := parse(t, "timer_stop_violation.go")
+	d := detectors.NewTimerStopDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 TimerNotStopped issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if issues[0].Rule != "TimerNotStopped" || issues[0].Severity != "info" || issues[0].Line != 10 {
+		t.Fatalf("unexpected issue: %+v", issues[0])
+	}
+}
+
+func TestGlobalStateDetector_LoopMutation(t *testing.T) {
+	fset, node, file := parse(t, "global_loop_violation.go")
+	d := detectors.NewGlobalStateDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 NonDeterminism issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Rule != "NonDeterminism" || is.Severity != "error" || is.Func != "CountingWorkflow" {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestGlobalStateDetector_CrossFileVar(t *testing.T) {
+	_, varsNode, _ := parse(t, "global_cross_file_vars.go")
+	fset, node, file := parse(t, "global_cross_file_workflow.go")
+
+	// The var is declared in a sibling file of the same package.
+	reg := registry.NewWorkflowRegistry()
+	reg.ProcessFile(varsNode, "testdata/testdata", importMapFromFile(varsNode))
+
+	issues := walkWithRegistry(t, detectors.NewGlobalStateDetector(), reg, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 NonDeterminism issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if is := issues[0]; is.Rule != "NonDeterminism" || is.Severity != "error" || is.Func != "TallyWorkflow" || is.Line != 9 {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestGlobalVarDetector(t *testing.T) {
+	fset, node, file := parse(t, "global_var_violation.go")
+	d := detectors.NewGlobalVarDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	want := []struct {
+		line   int
+		access string
+	}{
+		{18, "counter is assigned"},
+		{18, "counter is read"},
+		{25, "retryLabel is read"}, // the struct literal key on line 23 is a field name
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d NonDeterminism issues in %s, got %d: %+v", len(want), file, len(issues), issues)
+	}
+	for i, w := range want {
+		is := issues[i]
+		if is.Rule != "NonDeterminism" || is.Severity != "warning" || is.Func != "AttemptWorkflow" || is.Line != w.line || !strings.Contains(is.Message, w.access) {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+
+	// Loop mutations are GlobalStateDetector's errors, not repeated here.
+	fset, node, file = parse(t, "global_loop_violation.go")
+	for _, is := range walkOnce(t, detectors.NewGlobalVarDetector(), fset, node, file) {
+		if is.Line == 13 || is.Line == 14 {
+			t.Errorf("loop mutation reported twice: %+v", is)
+		}
+	}
+}
+
+func TestGlobalVarDetector_CrossFileLoopWrite(t *testing.T) {
+	_, varsNode, _ := parse(t, "global_cross_file_vars.go")
+	fset, node, file := parse(t, "global_cross_file_workflow.go")
+	reg := registry.NewWorkflowRegistry()
+	reg.ProcessFile(varsNode, "testdata/testdata", importMapFromFile(varsNode))
+
+	// The loop write to a var from another file is reported exactly once,
+	// as GlobalStateDetector's error.
+	var issues []detectors.Issue
+	issues = append(issues, walkWithRegistry(t, detectors.NewGlobalVarDetector(), reg, fset, node, file)...)
+	issues = append(issues, walkWithRegistry(t, detectors.NewGlobalStateDetector(), reg, fset, node, file)...)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue for the loop write in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if is := issues[0]; is.Severity != "error" || is.Line != 9 || !strings.Contains(is.Message, "inside a loop") {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestMapRangeDetector(t *testing.T) {
+	fset, node, file := parse(t, "map_range_violation.go")
+	d := detectors.NewMapRangeDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 4 {
+		t.Fatalf("expected 4 NonDeterminism issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Rule != "NonDeterminism" || is.Severity != "warning" || is.Func != "FanOutByRegionWorkflow" {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestReceiverMutationDetector(t *testing.T) {
+	fset, node, file := parse(t, "receiver_mutation_violation.go")
+	d := detectors.NewReceiverMutationDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 ReceiverMutation issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Rule != "ReceiverMutation" || is.Severity != "warning" || is.Func != "(orderWorkflows).ProcessOrder" {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestSyncPrimitiveDetector(t *testing.T) {
+	fset, node, file := parse(t, "sync_primitive_violation.go")
+	d := detectors.NewSyncPrimitiveDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 6 {
+		t.Fatalf("expected 6 Concurrency issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for i, is := range issues {
+		want := "ParallelStepsWorkflow"
+		if i >= 4 {
+			want = "LockedStepWorkflow"
+		}
+		if is.Rule != "Concurrency" || is.Severity != "warning" || is.Func != want {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+	if !strings.Contains(issues[3].Message, "sync.WaitGroup.Wait() on wg") {
+		t.Errorf("unexpected message: %q", issues[3].Message)
+	}
+}
+
+func TestContextMisuseDetector_ActivityDeadline(t *testing.T) {
+	fset, node, file := parse(t, "context_timeout_violation.go")
+	d := detectors.NewContextMisuseDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 ContextMisuse issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for _, is := range issues {
+		if is.Rule != "ContextMisuse" || is.Severity != "warning" || is.Func != "BoundedChargeWorkflow" || !strings.Contains(is.Message, "StartToCloseTimeout") {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestActivityTimeoutDetector(t *testing.T) {
+	fset, node, file := parse(t, "activity_timeout_violation.go")
+	d := detectors.NewActivityTimeoutDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 MissingTimeout issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if is := issues[0]; is.Rule != "MissingTimeout" || is.Severity != "error" || is.Line != 10 {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestDirectActivityCallDetector(t *testing.T) {
+	fset, node, file := parse(t, "direct_activity_violation.go")
+	d := detectors.NewDirectActivityCallDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 DirectActivityCall issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if is := issues[0]; is.Rule != "DirectActivityCall" || is.Severity != "error" || is.Func != "PaymentWorkflow" || is.Line != 11 {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestPlatformDependentDetector(t *testing.T) {
+	fset, node, file := parse(t, "platform_dependent_violation.go")
+	d := detectors.NewPlatformDependentDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 PlatformDependent issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if is := issues[0]; is.Rule != "PlatformDependent" || is.Severity != "info" || is.Func != "QuotaWorkflow" || is.Line != 11 {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestPointerIdentityDetector(t *testing.T) {
+	fset, node, file := parse(t, "pointer_identity_violation.go")
+	d := detectors.NewPointerIdentityDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 PointerIdentity issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if is := issues[0]; is.Rule != "PointerIdentity" || is.Severity != "info" || is.Func != "DedupeWorkflow" || is.Line != 13 {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestUnawaitedGoDetector(t *testing.T) {
+	fset, node, file := parse(t, "unawaited_go_violation.go")
+	d := detectors.NewUnawaitedGoDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 UnawaitedWorkflowGo issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for i, fn := range []string{"FireAndForgetWorkflow", "SelfWaitingWorkflow"} {
+		if is := issues[i]; is.Rule != "UnawaitedWorkflowGo" || is.Severity != "info" || is.Func != fn {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+}
+
+func TestUnregisteredWorkflowDetector(t *testing.T) {
+	fset, node, file := parse(t, "unregistered_workflow.go")
+	d := detectors.NewUnregisteredWorkflowDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 UnregisteredWorkflow issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if is := issues[0]; is.Rule != "UnregisteredWorkflow" || is.Severity != "info" || is.Func != "ForgottenReportWorkflow" {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestImportDetector_NoRegistry(t *testing.T) {
+	fset, node, file := parse(t, "rand_violation.go")
+	d := detectors.NewImportDetector([]config.ImportRule{{Rule: "ImportRandom", Path: "math/rand", Severity: "warning"}})
+	d.SetFileContext(detectors.FileContext{File: file, Fset: fset, ImportMap: importMapFromFile(node)})
+
+	ast.Walk(d, node)
+	if issues := d.Issues(); len(issues) != 0 {
+		t.Fatalf("expected no issues without a registry, got %+v", issues)
+	}
+}
+
+func TestBuildDependentDetection(t *testing.T) {
+	rules, err := config.LoadRules("../config/rules.yaml")
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	fset, node, file := parse(t, "build_dependent_violation.go")
+	d := detectors.NewFuncCallDetector(rules.FunctionCalls, rules.ExternalPackages, rules.SafeExternalPackages, nil)
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 BuildDependent issues in %s, got %d: %+v", file, len(issues), issues)
+	}
+	for i, want := range []string{"runtime.Version()", "debug.ReadBuildInfo()"} {
+		is := issues[i]
+		if is.Rule != "BuildDependent" || is.Severity != "info" || is.Func != "BuildAwareWorkflow" || !strings.Contains(is.Message, want) {
+			t.Errorf("issue %d: unexpected %+v", i, is)
+		}
+	}
+}
+
+func TestChannelDetector_WorkflowGoClosure(t *testing.T) {
+	fset, node, file := parse(t, "go_closure_select_violation.go")
+	d := detectors.NewChannelDetector()
+	issues := walkOnce(t, d, fset, node, file)
+
+	var selects []detectors.Issue
+	channels := 0
+	for _, is := range issues {
+		switch {
+		case strings.Contains(is.Message, "native select"):
+			selects = append(selects, is)
+		case strings.Contains(is.Message, "channel creation"):
+			channels++
+			if is.Func != "MigratedPumpWorkflow" {
+				t.Errorf("unexpected channel issue: %+v", is)
+			}
+		}
+	}
+	if channels != 2 {
+		t.Errorf("expected 2 native channel issues, got %d: %+v", channels, issues)
+	}
+	if len(selects) != 2 {
+		t.Fatalf("expected 2 native select issues in %s, got %d: %+v", file, len(selects), issues)
+	}
+	if selects[0].Line != 13 || selects[0].Func != "MigratedPumpWorkflow" || selects[0].Severity != "warning" {
+		t.Errorf("unexpected select issue in the workflow's closure: %+v", selects[0])
+	}
+	if selects[1].Line != 24 || selects[1].Func != "startPump" || selects[1].Severity != "error" {
+		t.Errorf("unexpected select issue in the helper's closure: %+v", selects[1])
+	}
+}
+
+func TestUnknownActivityNameDetector(t *testing.T) {
+	fset, node, file := parse(t, "unknown_activity_name_violation.go")
+	d := detectors.NewUnknownActivityNameDetector()
+	issues := walkOnce(t, d, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 UnknownActivityName issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	is := issues[0]
+	if is.Rule != "UnknownActivityName" || is.Severity != "warning" || is.Line != 23 || is.Func != "FulfillOrderWorkflow" || !strings.Contains(is.Message, `"shipProdcut"`) {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+
+	// Without any activity registration there is nothing to compare against.
+	fset, node, file = parse(t, "activity_timeout_violation.go")
+	if issues := walkOnce(t, detectors.NewUnknownActivityNameDetector(), fset, node, file); len(issues) != 0 {
+		t.Errorf("expected no issues without registrations, got %+v", issues)
+	}
+}
+
+func TestRegistry_ActivityNames(t *testing.T) {
+	_, node, _ := parse(t, "unknown_activity_name_violation.go")
+	reg := registry.NewWorkflowRegistry()
+	reg.ProcessFile(node, "testdata/testdata", importMapFromFile(node))
+	for _, name := range []string{"shipProduct", "chargeCard", "testdata/testdata.chargeCard"} {
+		if registered, ok := reg.ActivityNameRegistered(name); !ok || !registered {
+			t.Errorf("expected %q to be a registered activity name", name)
+		}
+	}
+
+	// A struct of activities registers its methods under names the linter
+	// doesn't derive, so no name can be ruled out.
+	src := `package acts
+
+import "go.uber.org/cadence/worker"
+
+type Activities struct{}
+
+func register(w worker.Worker) {
+	w.RegisterActivity(&Activities{})
+}
+`
+	node, err := parser.ParseFile(token.NewFileSet(), "acts.go", src, 0)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	reg.ProcessFile(node, "example.com/acts", importMapFromFile(node))
+	if _, ok := reg.ActivityNameRegistered("Anything"); ok {
+		t.Error("expected struct registration to make activity names undecidable")
+	}
+}
+
+func TestFuncCallDetector_RegexRules(t *testing.T) {
+	rules := []config.FunctionRule{
+		{Rule: "TimeUsage", Package: "time", Functions: []string{"Now"}, Severity: "error", Message: "Detected time.%FUNC%()"},
+		{Rule: "MustPanics", Package: "regexp", FunctionsRegex: "Must.*", Severity: "warning", Message: "Detected %PKG%.%FUNC%()"},
+	}
+	external := []config.ExternalPackageRule{
+		{Rule: "AWSSDK", PackageRegex: `github\.com/aws/aws-sdk-go/.*`, Severity: "error", Message: "Detected %PKG%.%FUNC%()"},
+	}
+
+	fset, node, file := parse(t, "regex_rule_violation.go")
+	d := detectors.NewFuncCallDetector(rules, external, nil, nil)
+	d.SetUnknownExternalCall("off")
+	var got []string
+	for _, is := range walkOnce(t, d, fset, node, file) {
+		got = append(got, fmt.Sprintf("%d:%s", is.Line, is.Rule))
+	}
+	want := []string{"15:AWSSDK", "15:AWSSDK", "16:AWSSDK", "18:MustPanics", "20:TimeUsage"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestValidateRegexRules(t *testing.T) {
+	bad := []config.FunctionRule{
+		{Rule: "Broken", PackageRegex: "github.com/(", Severity: "error"},
+		{Rule: "Both", Package: "time", PackageRegex: "time", Severity: "error"},
+	}
+	for _, r := range bad {
+		if err := config.Validate(&config.RuleSet{FunctionCalls: []config.FunctionRule{r}}); err == nil {
+			t.Errorf("expected rule %q to be rejected", r.Rule)
+		}
+	}
+}
+
+func TestSyncPrimitiveDetector_Cond(t *testing.T) {
+	fset, node, file := parse(t, "sync_cond_violation.go")
+	d := detectors.NewSyncPrimitiveDetector()
+	var conds []detectors.Issue
+	for _, is := range walkOnce(t, d, fset, node, file) {
+		if strings.Contains(is.Message, "sync.Mutex") {
+			if is.Severity != "warning" {
+				t.Errorf("expected other primitives to stay warnings, got %+v", is)
+			}
+			continue
+		}
+		conds = append(conds, is)
+	}
+	want := []struct {
+		line int
+		fn   string
+		what string
+	}{
+		{12, "ReadyGateWorkflow", "sync.NewCond()"},
+		{14, "ReadyGateWorkflow", "sync.Cond.Broadcast() on ready"},
+		{17, "ReadyGateWorkflow", "sync.Cond.Wait() on ready"},
+		{22, "waitReady", "sync.Cond.Signal() on c"},
+	}
+	if len(conds) != len(want) {
+		t.Fatalf("expected %d sync.Cond issues in %s, got %d: %+v", len(want), file, len(conds), conds)
+	}
+	for i, w := range want {
+		is := conds[i]
+		if is.Rule != "Concurrency" || is.Severity != "error" || is.Line != w.line || is.Func != w.fn || !strings.Contains(is.Message, w.what) {
+			t.Errorf("issue %d: got %+v, want line %d in %s about %s", i, is, w.line, w.fn, w.what)
+		}
+	}
+}
+
+func TestSyncPrimitiveDetector_LazyInit(t *testing.T) {
+	fset, node, file := parse(t, "lazy_init_violation.go")
+	d := detectors.NewSyncPrimitiveDetector()
+	var lazy []detectors.Issue
+	for _, is := range walkOnce(t, d, fset, node, file) {
+		if is.Rule == "LazyInit" {
+			lazy = append(lazy, is)
+		} else if is.Func != "ConvertLocalOnceWorkflow" {
+			t.Errorf("unexpected issue: %+v", is)
+		}
+	}
+	if len(lazy) != 1 {
+		t.Fatalf("expected 1 LazyInit issue in %s, got %d: %+v", file, len(lazy), lazy)
+	}
+	if is := lazy[0]; is.Severity != "warning" || is.Func != "ConvertWorkflow" || is.Line != 20 || !strings.Contains(is.Message, "ratesOnce") {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestSyncPrimitiveDetector_LazyInitCrossFile(t *testing.T) {
+	_, varsNode, _ := parse(t, "lazy_init_cross_file_vars.go")
+	fset, node, file := parse(t, "lazy_init_cross_file_workflow.go")
+	reg := registry.NewWorkflowRegistry()
+	reg.ProcessFile(varsNode, "testdata/testdata", importMapFromFile(varsNode))
+
+	issues := walkWithRegistry(t, detectors.NewSyncPrimitiveDetector(), reg, fset, node, file)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 LazyInit issue in %s, got %d: %+v", file, len(issues), issues)
+	}
+	if is := issues[0]; is.Rule != "LazyInit" || is.Severity != "warning" || is.Func != "ConfigWorkflow" || is.Line != 8 {
+		t.Errorf("unexpected issue: %+v", is)
+	}
+}
+
+func TestGlobalVarDetector_SkipsLazyInitOnce(t *testing.T) {
+	_, varsNode, _ := parse(t, "lazy_init_cross_file_vars.go")
+	reg := registry.NewWorkflowRegistry()
+	reg.ProcessFile(varsNode, "testdata/testdata", importMapFromFile(varsNode))
+
+	// Same-file and cross-file onces are reported once, as LazyInit.
+	for _, name := range []string{"lazy_init_violation.go", "lazy_init_cross_file_workflow.go"} {
+		fset, node, file := parse(t, name)
+		for _, is := range walkWithRegistry(t, detectors.NewGlobalVarDetector(), reg, fset, node, file) {
+			if strings.Contains(is.Message, "Once is read") {
+				t.Errorf("sync.Once.Do reported as a package-level read: %+v", is)
+			}
+		}
+	}
+}
+
+func TestEmbedFSDetector(t *testing.T) {
+	_, otherNode, _ := parse(t, "fs_violation.go")
+	fset, node, file := parse(t, "embed_fs_violation.go")
+	reg := registry.NewWorkflowRegistry()
+	reg.ProcessFile(otherNode, "testdata/testdata", importMapFromFile(otherNode))
+
+	issues := walkWithRegistry(t, detectors.NewEmbedFSDetector(), reg, fset, node, file)
+	want := []struct {
+		line int
+		what string
+	}{
+		{14, "embed.FS.ReadFile() on emailTemplates"},
+		{18, "embed.FS.ReadDir() on templates"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d IOCalls issues in %s, got %d: %+v", len(want), file, len(issues), issues)
+	}
+	for i, w := range want {
+		if is := issues[i]; is.Rule != "IOCalls" || is.Severity != "warning" || is.Func != "EmailWorkflow" || is.Line != w.line || !strings.Contains(is.Message, w.what) {
+			t.Errorf("issue %d: got %+v, want line %d about %s", i, is, w.line, w.what)
+		}
+	}
+
+	// The reads aren't reported again as package-level variable reads.
+	if dup := walkWithRegistry(t, detectors.NewGlobalVarDetector(), reg, fset, node, file); len(dup) != 0 {
+		t.Errorf("expected no package-level variable issues, got %+v", dup)
+	}
+}
@@ -0,0 +1,49 @@
+// Package vcs reads version-control metadata to annotate reports.
+package vcs
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// Git runs git subcommands and returns their trimmed standard output.
+// Implementations other than ExecGit exist for tests.
+type Git interface {
+	Run(args ...string) (string, error)
+}
+
+// ExecGit runs the git binary in Dir (the current directory when empty).
+type ExecGit struct {
+	Dir string
+}
+
+// Run implements Git.
+func (g ExecGit) Run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.Dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Revision identifies the commit a report was produced from.
+type Revision struct {
+	Commit string `json:"commit" yaml:"commit"`
+	Branch string `json:"branch,omitempty" yaml:"branch,omitempty"` // empty on a detached HEAD
+}
+
+// Head returns the HEAD revision, or nil when git is unavailable or the
+// directory isn't inside a repository.
+func Head(g Git) *Revision {
+	sha, err := g.Run("rev-parse", "HEAD")
+	if err != nil || sha == "" {
+		return nil
+	}
+	rev := &Revision{Commit: sha}
+	if branch, err := g.Run("rev-parse", "--abbrev-ref", "HEAD"); err == nil && branch != "HEAD" {
+		rev.Branch = branch
+	}
+	return rev
+}
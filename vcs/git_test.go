@@ -0,0 +1,42 @@
+package vcs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeGit answers git commands from a table keyed by the joined arguments.
+type fakeGit map[string]string
+
+func (f fakeGit) Run(args ...string) (string, error) {
+	out, ok := f[strings.Join(args, " ")]
+	if !ok {
+		return "", errors.New("fatal: not a git repository")
+	}
+	return out, nil
+}
+
+func TestHead(t *testing.T) {
+	g := fakeGit{
+		"rev-parse HEAD":              "3f2a9c1d0b7e",
+		"rev-parse --abbrev-ref HEAD": "feature/timers",
+	}
+	rev := Head(g)
+	if rev == nil || rev.Commit != "3f2a9c1d0b7e" || rev.Branch != "feature/timers" {
+		t.Fatalf("unexpected revision: %+v", rev)
+	}
+}
+
+func TestHeadDetached(t *testing.T) {
+	rev := Head(fakeGit{"rev-parse HEAD": "3f2a9c1d0b7e", "rev-parse --abbrev-ref HEAD": "HEAD"})
+	if rev == nil || rev.Branch != "" {
+		t.Fatalf("expected a revision without branch, got %+v", rev)
+	}
+}
+
+func TestHeadOutsideRepository(t *testing.T) {
+	if rev := Head(fakeGit{}); rev != nil {
+		t.Fatalf("expected no revision outside a repository, got %+v", rev)
+	}
+}
@@ -0,0 +1,131 @@
+// Package watch re-runs the linter when Go files under a directory change.
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultDebounce is how long the file tree must stay quiet before a re-lint.
+const DefaultDebounce = 300 * time.Millisecond
+
+// Source delivers the paths of changed files.
+type Source interface {
+	Events() <-chan string
+	Errors() <-chan error
+	Close() error
+}
+
+// Run calls lint once, then again whenever .go files change. Bursts of events
+// (editors often write a file several times on save) are collapsed into a
+// single re-run once no event has arrived for debounce. Run returns when ctx
+// is done or the source's event channel is closed.
+func Run(ctx context.Context, src Source, debounce time.Duration, lint func()) error {
+	lint()
+
+	var timer *time.Timer
+	var fire <-chan time.Time
+	errs := src.Errors()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case path, ok := <-src.Events():
+			if !ok {
+				return nil
+			}
+			if filepath.Ext(path) != ".go" {
+				continue
+			}
+			if timer == nil {
+				timer = time.NewTimer(debounce)
+			} else {
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(debounce)
+			}
+			fire = timer.C
+		case <-fire:
+			fire = nil
+			lint()
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			return err
+		}
+	}
+}
+
+// fsSource adapts an fsnotify watcher over a directory tree to Source.
+type fsSource struct {
+	w      *fsnotify.Watcher
+	events chan string
+	done   chan struct{}
+}
+
+// NewFSSource watches root and all its subdirectories, including ones created later.
+func NewFSSource(root string) (Source, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	s := &fsSource{w: w, events: make(chan string), done: make(chan struct{})}
+	if err := s.addTree(root); err != nil {
+		w.Close()
+		return nil, err
+	}
+	go s.forward()
+	return s, nil
+}
+
+func (s *fsSource) addTree(root string) error {
+	return filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(fi.Name(), ".") {
+			return filepath.SkipDir // .git and friends
+		}
+		return s.w.Add(path)
+	})
+}
+
+func (s *fsSource) forward() {
+	defer close(s.events)
+	for ev := range s.w.Events {
+		if ev.Has(fsnotify.Create) {
+			if fi, err := os.Stat(ev.Name); err == nil && fi.IsDir() {
+				_ = s.addTree(ev.Name)
+			}
+		}
+		if ev.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Remove|fsnotify.Rename) == 0 {
+			continue
+		}
+		select {
+		case s.events <- ev.Name:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *fsSource) Events() <-chan string { return s.events }
+func (s *fsSource) Errors() <-chan error  { return s.w.Errors }
+func (s *fsSource) Close() error {
+	close(s.done)
+	return s.w.Close()
+}
@@ -0,0 +1,71 @@
+package watch
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeSource struct {
+	events chan string
+	errs   chan error
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{events: make(chan string), errs: make(chan error)}
+}
+
+func (f *fakeSource) Events() <-chan string { return f.events }
+func (f *fakeSource) Errors() <-chan error  { return f.errs }
+func (f *fakeSource) Close() error          { close(f.events); return nil }
+
+func TestRunDebouncesBursts(t *testing.T) {
+	src := newFakeSource()
+	var runs atomic.Int32
+	reran := make(chan struct{}, 10)
+	lint := func() {
+		if runs.Add(1) > 1 {
+			reran <- struct{}{}
+		}
+	}
+
+	done := make(chan error)
+	go func() { done <- Run(context.Background(), src, 50*time.Millisecond, lint) }()
+
+	// A burst of saves, plus a non-Go file that must be ignored on its own.
+	for _, p := range []string{"a.go", "a.go", "b.go", "notes.txt"} {
+		src.events <- p
+	}
+	select {
+	case <-reran:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a re-run after the burst settled")
+	}
+
+	src.events <- "README.md"
+	time.Sleep(150 * time.Millisecond)
+	src.Close()
+	if err := <-done; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := runs.Load(); got != 2 {
+		t.Fatalf("expected initial run plus one debounced re-run, got %d runs", got)
+	}
+}
+
+func TestRunStopsOnContextCancel(t *testing.T) {
+	src := newFakeSource()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- Run(ctx, src, time.Millisecond, func() {}) }()
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not stop after cancel")
+	}
+}